@@ -1,6 +1,45 @@
 package v1alpha1
 
-import apiv1 "k8s.io/api/core/v1"
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Condition types reported in the status of HigressGateway and HigressController.
+const (
+	// ConditionReady is true when all desired replicas of the managed Deployment are updated and available.
+	ConditionReady = "Ready"
+	// ConditionProgressing is true while the managed Deployment is rolling out.
+	ConditionProgressing = "Progressing"
+	// ConditionDegraded is true when the rollout of the managed Deployment is failing.
+	ConditionDegraded = "Degraded"
+	// ConditionReconcileError is true when the last reconcile failed at one of its steps.
+	ConditionReconcileError = "ReconcileError"
+	// ConditionFieldConflict is true when the last reconcile rendered fields owned by other field managers.
+	ConditionFieldConflict = "FieldConflict"
+	// ConditionGatewayConfigAvailable is true when the mesh config of the gateway of a
+	// HigressController exists, the controller is only deployed once it does.
+	ConditionGatewayConfigAvailable = "GatewayConfigAvailable"
+	// ConditionIngressClassConflict is true when an older HigressController serves the ingress class
+	// of a HigressController, which is then not reconciled.
+	ConditionIngressClassConflict = "IngressClassConflict"
+	// ConditionCRDsSkipped is true when no CRDs are embedded for the Higress release of the image of
+	// a HigressController, whose CRDs then have to be installed in another way.
+	ConditionCRDsSkipped = "CRDsSkipped"
+)
+
+// +k8s:deepcopy-gen=true
+
+// ResourceRef identifies an object applied by the operator for an instance.
+type ResourceRef struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
 
 // +k8s:deepcopy-gen=true
 
@@ -18,6 +57,18 @@ type CRDCommonFields struct {
 	// +kubebuilder:validation:Optional
 	Toleration []apiv1.Toleration `json:"toleration"`
 	// +kubebuilder:validation:Optional
+	TopologySpreadConstraints []apiv1.TopologySpreadConstraint `json:"topologySpreadConstraints"`
+	// +kubebuilder:validation:Optional
+	PriorityClassName string `json:"priorityClassName"`
+	// +kubebuilder:validation:Optional
+	SchedulerName string `json:"schedulerName"`
+	// +kubebuilder:validation:Optional
+	// +nullable
+	RuntimeClassName *string `json:"runtimeClassName"`
+	// +kubebuilder:validation:Optional
+	// +nullable
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds"`
+	// +kubebuilder:validation:Optional
 	// +nullable
 	Service *Service `json:"service"`
 	// +kubebuilder:validation:Optional
@@ -27,6 +78,11 @@ type CRDCommonFields struct {
 	// +kubebuilder:validation:Optional
 	// +nullable
 	AutoScaling *AutoScaling `json:"autoScaling"`
+	// PodDisruptionBudget limits the pods that voluntary disruptions, such as node drains, may
+	// evict at once.
+	// +kubebuilder:validation:Optional
+	// +nullable
+	PodDisruptionBudget *PodDisruptionBudget `json:"podDisruptionBudget"`
 	// +kubebuilder:validation:Optional
 	// +nullable
 	PodSecurityContext *apiv1.PodSecurityContext `json:"podSecurityContext"`
@@ -50,8 +106,34 @@ type CRDCommonFields struct {
 	Local        bool          `json:"local"`
 	// +kubebuilder:validation:Enum=third-party-jwt;first-party-jwt
 	JwtPolicy string `json:"jwtPolicy"`
+	// DriftPolicy decides what happens to managed objects that were changed outside the operator,
+	// Enforce reverts the changes and ReportOnly only reports them. Defaults to Enforce.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Enforce;ReportOnly
+	// +kubebuilder:default=Enforce
+	DriftPolicy DriftPolicy `json:"driftPolicy"`
+	// DeletionPolicy decides what happens to the managed objects when the CR is deleted, Delete
+	// removes them and Retain keeps them running without the CR. Defaults to Delete.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy"`
 }
 
+type DriftPolicy string
+
+const (
+	DriftPolicyEnforce    DriftPolicy = "Enforce"
+	DriftPolicyReportOnly DriftPolicy = "ReportOnly"
+)
+
+type DeletionPolicy string
+
+const (
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+)
+
 // +k8s:deepcopy-gen=true
 
 type ContainerCommonFields struct {
@@ -82,7 +164,9 @@ type ContainerCommonFields struct {
 
 type Image struct {
 	Repository string `json:"repository"`
-	Tag        string `json:"tag"`
+	// Tag is the tag of the image, such as 1.3.0, a digest, such as sha256:<hex>, or both, such as
+	// 1.3.0@sha256:<hex>.
+	Tag string `json:"tag"`
 	// +kubebuilder:validation:Enum="";Always;Never;IfNotPresent
 	ImagePullPolicy apiv1.PullPolicy `json:"imagePullPolicy"`
 }
@@ -100,10 +184,53 @@ type ServiceAccount struct {
 // +k8s:deepcopy-gen=true
 
 type AutoScaling struct {
-	Enable                         bool   `json:"enable"`
-	MinReplicas                    *int32 `json:"minReplicas"`
-	MaxReplicas                    int32  `json:"maxReplicas"`
+	Enable      bool   `json:"enable"`
+	MinReplicas *int32 `json:"minReplicas"`
+	MaxReplicas int32  `json:"maxReplicas"`
+	// +kubebuilder:validation:Optional
 	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage"`
+	// +kubebuilder:validation:Optional
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage"`
+	// Metrics are appended to the cpu and memory targets, e.g. a Pods metric on the
+	// envoy_server_downstream_cx_active statistic scraped from port 15090.
+	// +kubebuilder:validation:Optional
+	Metrics []autoscalingv2.MetricSpec `json:"metrics"`
+	// Behavior configures the scale-up and scale-down policies, it requires autoscaling/v2beta2 or newer.
+	// +kubebuilder:validation:Optional
+	// +nullable
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// PodDisruptionBudget configures the PodDisruptionBudget of the pods of an instance. At most one
+// of minAvailable and maxUnavailable can be set, the budget lets one pod be evicted at a time if none is.
+type PodDisruptionBudget struct {
+	Enable bool `json:"enable"`
+	// +kubebuilder:validation:Optional
+	// +nullable
+	MinAvailable *intstr.IntOrString `json:"minAvailable"`
+	// +kubebuilder:validation:Optional
+	// +nullable
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable"`
+	// UnhealthyPodEvictionPolicy decides when unhealthy pods may be evicted, IfHealthyBudget only
+	// evicts them while the budget is met and AlwaysAllow evicts them regardless. Defaults to the
+	// policy of the cluster.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum="";IfHealthyBudget;AlwaysAllow
+	UnhealthyPodEvictionPolicy string `json:"unhealthyPodEvictionPolicy"`
+}
+
+// +k8s:deepcopy-gen=true
+
+type AutoScalingStatus struct {
+	// CurrentReplicas is the number of replicas last observed by the autoscaler.
+	CurrentReplicas int32 `json:"currentReplicas"`
+	// DesiredReplicas is the number of replicas last calculated by the autoscaler.
+	DesiredReplicas int32 `json:"desiredReplicas"`
+	// +kubebuilder:validation:Optional
+	// +nullable
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
 }
 
 // +k8s:deepcopy-gen=true
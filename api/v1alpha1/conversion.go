@@ -0,0 +1,126 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"sort"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AnnotationConversionData keeps the fields of an object that the version it was converted to
+// can't represent, so that converting it back restores them.
+const AnnotationConversionData = "operator.higress.io/conversion-data"
+
+// conversionData holds the fields that only one of the versions can represent.
+// +kubebuilder:object:generate=false
+type conversionData struct {
+	// Local is the Local field of v1alpha1, which v1beta1 replaced with explicit ports.
+	Local bool `json:"local,omitempty"`
+	// Resources are the resources of a local v1alpha1 gateway, which were ignored.
+	Resources *apiv1.ResourceRequirements `json:"resources,omitempty"`
+	// LocalPorts is true if the ports of the v1beta1 gateway are the ones of a local gateway.
+	LocalPorts bool `json:"localPorts,omitempty"`
+	// Env are the v1beta1 environment variables with a valueFrom, keyed by the path of the env.
+	Env map[string][]apiv1.EnvVar `json:"env,omitempty"`
+}
+
+func getConversionData(obj client.Object) (*conversionData, error) {
+	data := &conversionData{}
+	if value, ok := obj.GetAnnotations()[AnnotationConversionData]; ok {
+		if err := json.Unmarshal([]byte(value), data); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+func setConversionData(obj client.Object, data *conversionData) error {
+	annotations := obj.GetAnnotations()
+	delete(annotations, AnnotationConversionData)
+	if data.Local || data.Resources != nil || data.LocalPorts || len(data.Env) > 0 {
+		value, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[AnnotationConversionData] = string(value)
+	}
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// convertObject copies the fields of src to dst, which have the same names in both versions.
+// The fields of dropped differ between the versions and are converted by the caller.
+func convertObject(src, dst runtime.Object, dropped ...[]string) error {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(src)
+	if err != nil {
+		return err
+	}
+
+	// the type of dst is set by the caller
+	delete(content, "apiVersion")
+	delete(content, "kind")
+	for _, fields := range dropped {
+		unstructured.RemoveNestedField(content, fields...)
+	}
+
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(content, dst)
+}
+
+// toEnvVars converts the env of v1alpha1 to the environment variables of v1beta1, sorted by name.
+func toEnvVars(env map[string]string) []apiv1.EnvVar {
+	if env == nil {
+		return nil
+	}
+
+	envs := make([]apiv1.EnvVar, 0, len(env))
+	for name, value := range env {
+		envs = append(envs, apiv1.EnvVar{Name: name, Value: value})
+	}
+	sort.Slice(envs, func(i, j int) bool {
+		return envs[i].Name < envs[j].Name
+	})
+	return envs
+}
+
+// fromEnvVars converts the environment variables of v1beta1 to the env of v1alpha1, and returns
+// the variables with a valueFrom separately, as v1alpha1 can't represent them.
+func fromEnvVars(envs []apiv1.EnvVar) (map[string]string, []apiv1.EnvVar) {
+	if envs == nil {
+		return nil, nil
+	}
+
+	env := make(map[string]string, len(envs))
+	var valueFrom []apiv1.EnvVar
+	for _, e := range envs {
+		if e.ValueFrom != nil {
+			valueFrom = append(valueFrom, e)
+			continue
+		}
+		env[e.Name] = e.Value
+	}
+	return env, valueFrom
+}
+
+// restoreEnvVars appends the variables with a valueFrom kept by a previous conversion to envs,
+// unless v1alpha1 has since set a variable of the same name.
+func restoreEnvVars(envs, valueFrom []apiv1.EnvVar) []apiv1.EnvVar {
+	names := make(map[string]struct{}, len(envs))
+	for _, e := range envs {
+		names[e.Name] = struct{}{}
+	}
+	for _, e := range valueFrom {
+		if _, ok := names[e.Name]; !ok {
+			envs = append(envs, e)
+		}
+	}
+	return envs
+}
@@ -0,0 +1,120 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+func TestHigressGatewayRoundTrip(t *testing.T) {
+	replicas := int32(2)
+	src := &HigressGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "edge",
+			Namespace:   "tenant",
+			Annotations: map[string]string{"team": "edge"},
+		},
+	}
+	src.Spec.Replicas = &replicas
+	src.Spec.SelectorLabels = map[string]string{"app": "edge"}
+	src.Spec.NetWorkGateway = "network1"
+	src.Spec.Local = true
+	src.Spec.Image = Image{Repository: "higress/gateway", Tag: "1.3.0"}
+	src.Spec.Env = map[string]string{"B": "2", "A": "1"}
+	src.Spec.Resources = &apiv1.ResourceRequirements{
+		Requests: apiv1.ResourceList{apiv1.ResourceCPU: resource.MustParse("500m")},
+	}
+
+	hub := &v1beta1.HigressGateway{}
+	if err := src.DeepCopy().ConvertTo(hub); err != nil {
+		t.Fatalf("ConvertTo() error = %v", err)
+	}
+	if hub.Spec.NetworkGateway != "network1" {
+		t.Errorf("networkGateway = %q, want network1", hub.Spec.NetworkGateway)
+	}
+	if hub.Spec.Resources != nil {
+		t.Errorf("resources = %v, want nil for a local gateway", hub.Spec.Resources)
+	}
+	if !equality.Semantic.DeepEqual(hub.Spec.Ports, localPorts) {
+		t.Errorf("ports = %v, want the local ports", hub.Spec.Ports)
+	}
+	wantEnv := []apiv1.EnvVar{{Name: "A", Value: "1"}, {Name: "B", Value: "2"}}
+	if !equality.Semantic.DeepEqual(hub.Spec.Env, wantEnv) {
+		t.Errorf("env = %v, want %v", hub.Spec.Env, wantEnv)
+	}
+
+	dst := &HigressGateway{}
+	if err := dst.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom() error = %v", err)
+	}
+	if !equality.Semantic.DeepEqual(dst, src) {
+		t.Errorf("round trip = %+v, want %+v", dst, src)
+	}
+}
+
+func TestHigressGatewayHubRoundTrip(t *testing.T) {
+	src := &v1beta1.HigressGateway{ObjectMeta: metav1.ObjectMeta{Name: "edge", Namespace: "tenant"}}
+	src.Spec.NetworkGateway = "network1"
+	src.Spec.Env = []apiv1.EnvVar{
+		{Name: "A", Value: "1"},
+		{Name: "POD_IP", ValueFrom: &apiv1.EnvVarSource{FieldRef: &apiv1.ObjectFieldSelector{FieldPath: "status.podIP"}}},
+	}
+	src.Spec.Ports = []apiv1.ContainerPort{{Name: "http", Protocol: "TCP", ContainerPort: 8080}}
+
+	spoke := &HigressGateway{}
+	if err := spoke.ConvertFrom(src.DeepCopy()); err != nil {
+		t.Fatalf("ConvertFrom() error = %v", err)
+	}
+	if _, ok := spoke.Annotations[AnnotationConversionData]; !ok {
+		t.Errorf("annotations = %v, want the env with a valueFrom kept", spoke.Annotations)
+	}
+
+	dst := &v1beta1.HigressGateway{}
+	if err := spoke.ConvertTo(dst); err != nil {
+		t.Fatalf("ConvertTo() error = %v", err)
+	}
+	if !equality.Semantic.DeepEqual(dst, src) {
+		t.Errorf("round trip = %+v, want %+v", dst, src)
+	}
+}
+
+func TestHigressControllerRoundTrip(t *testing.T) {
+	src := &HigressController{ObjectMeta: metav1.ObjectMeta{Name: "higress-controller", Namespace: "tenant"}}
+	src.Spec.Local = true
+	src.Spec.Controller.Env = map[string]string{"A": "1"}
+	src.Spec.Pilot.Env = map[string]string{"B": "2"}
+
+	hub := &v1beta1.HigressController{}
+	if err := src.DeepCopy().ConvertTo(hub); err != nil {
+		t.Fatalf("ConvertTo() error = %v", err)
+	}
+	if _, ok := hub.Annotations[AnnotationConversionData]; !ok {
+		t.Errorf("annotations = %v, want local kept", hub.Annotations)
+	}
+
+	// the variables with a valueFrom set in v1beta1 survive a round trip through v1alpha1
+	podIP := apiv1.EnvVar{Name: "POD_IP", ValueFrom: &apiv1.EnvVarSource{FieldRef: &apiv1.ObjectFieldSelector{FieldPath: "status.podIP"}}}
+	hub.Spec.Pilot.Env = append(hub.Spec.Pilot.Env, podIP)
+	want := hub.DeepCopy()
+
+	dst := &HigressController{}
+	if err := dst.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom() error = %v", err)
+	}
+	if !dst.Spec.Local || !equality.Semantic.DeepEqual(dst.Spec.Pilot.Env, src.Spec.Pilot.Env) {
+		t.Errorf("spec = %+v, want local and the pilot env %v", dst.Spec, src.Spec.Pilot.Env)
+	}
+
+	back := &v1beta1.HigressController{}
+	if err := dst.ConvertTo(back); err != nil {
+		t.Fatalf("ConvertTo() error = %v", err)
+	}
+	if !equality.Semantic.DeepEqual(back, want) {
+		t.Errorf("round trip = %+v, want %+v", back, want)
+	}
+}
@@ -0,0 +1,57 @@
+package v1alpha1
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+// ConvertTo converts this HigressController to the hub version v1beta1.
+func (src *HigressController) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.HigressController)
+	if err := convertObject(src, dst, []string{"spec", "controller", "env"}, []string{"spec", "pilot", "env"},
+		[]string{"spec", "local"}); err != nil {
+		return err
+	}
+
+	data, err := getConversionData(src)
+	if err != nil {
+		return err
+	}
+
+	dst.Spec.Controller.Env = restoreEnvVars(toEnvVars(src.Spec.Controller.Env), data.Env["spec.controller.env"])
+	dst.Spec.Pilot.Env = restoreEnvVars(toEnvVars(src.Spec.Pilot.Env), data.Env["spec.pilot.env"])
+	data.Env = nil
+
+	// the controller doesn't use Local, it is only kept for converting back
+	data.Local = src.Spec.Local
+	return setConversionData(dst, data)
+}
+
+// ConvertFrom converts the hub version v1beta1 to this HigressController.
+func (dst *HigressController) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.HigressController)
+	if err := convertObject(src, dst, []string{"spec", "controller", "env"}, []string{"spec", "pilot", "env"}); err != nil {
+		return err
+	}
+
+	data, err := getConversionData(src)
+	if err != nil {
+		return err
+	}
+
+	dst.Spec.Local = data.Local
+	data.Local = false
+
+	data.Env = map[string][]apiv1.EnvVar{}
+	var valueFrom []apiv1.EnvVar
+	if dst.Spec.Controller.Env, valueFrom = fromEnvVars(src.Spec.Controller.Env); len(valueFrom) > 0 {
+		data.Env["spec.controller.env"] = valueFrom
+	}
+	if dst.Spec.Pilot.Env, valueFrom = fromEnvVars(src.Spec.Pilot.Env); len(valueFrom) > 0 {
+		data.Env["spec.pilot.env"] = valueFrom
+	}
+
+	return setConversionData(dst, data)
+}
@@ -26,15 +26,68 @@ type HigressControllerSpec struct {
 
 	Controller ControllerSpec `json:"controller"`
 	Pilot      PilotSpec      `json:"pilot"`
+	// CRDInstallPolicy decides how the Higress CRDs are installed, Install creates and upgrades them,
+	// UpgradeOnly only upgrades the existing ones and Skip leaves them alone. Defaults to Install.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Install;Skip;UpgradeOnly
+	// +kubebuilder:default=Install
+	CRDInstallPolicy CRDInstallPolicy `json:"crdInstallPolicy"`
+	// GatewayAPI installs the Kubernetes Gateway API CRDs and enables the Gateway API support of
+	// the controller.
+	// +kubebuilder:validation:Optional
+	// +nullable
+	GatewayAPI *GatewayAPI `json:"gatewayAPI,omitempty"`
 }
 
+type CRDInstallPolicy string
+
+const (
+	CRDInstallPolicyInstall     CRDInstallPolicy = "Install"
+	CRDInstallPolicySkip        CRDInstallPolicy = "Skip"
+	CRDInstallPolicyUpgradeOnly CRDInstallPolicy = "UpgradeOnly"
+)
+
+type GatewayAPI struct {
+	// +kubebuilder:validation:Optional
+	Enable bool `json:"enable"`
+	// Version is the Gateway API release of the CRDs, such as v1.0.0. Defaults to the newest
+	// release embedded in the operator.
+	// +kubebuilder:validation:Optional
+	Version string `json:"version"`
+	// Channel is the release channel of the CRDs. Defaults to standard.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=standard;experimental
+	// +kubebuilder:default=standard
+	Channel GatewayAPIChannel `json:"channel"`
+}
+
+type GatewayAPIChannel string
+
+const (
+	GatewayAPIChannelStandard     GatewayAPIChannel = "standard"
+	GatewayAPIChannelExperimental GatewayAPIChannel = "experimental"
+)
+
 // HigressControllerStatus defines the observed state of HigressController
 type HigressControllerStatus struct {
-	Deployed bool `json:"deployed"`
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Inventory lists the objects applied for the instance, the ones that are no longer
+	// rendered from the spec are deleted.
+	// +kubebuilder:validation:Optional
+	Inventory []ResourceRef `json:"inventory,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+//+kubebuilder:deprecatedversion:warning="operator.higress.io/v1alpha1 HigressController is deprecated, use operator.higress.io/v1beta1"
 
 // HigressController is the Schema for the higresscontrollers API
 type HigressController struct {
@@ -57,8 +110,13 @@ type HigressControllerList struct {
 type ControllerSpec struct {
 	ContainerCommonFields `json:",inline"`
 
-	GatewayName  string `json:"gatewayName"`
+	GatewayName string `json:"gatewayName"`
+	// IngressClass is the name of the IngressClass and GatewayClass created for the controller.
 	IngressClass string `json:"ingressClass"`
+	// IsDefaultClass marks the IngressClass as the default class of the cluster.
+	// +kubebuilder:validation:Optional
+	IsDefaultClass bool `json:"isDefaultClass"`
+	// WatchNamespace is the namespace watched by the controller, all namespaces if empty.
 	// +kubebuilder:validation:Optional
 	WatchNamespace string `json:"watchNamespace"`
 	SDSTokenAud    string `json:"sdsTokenAud"`
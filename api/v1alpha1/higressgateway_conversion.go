@@ -0,0 +1,93 @@
+package v1alpha1
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+// localPorts are the ports of a local v1alpha1 gateway, which binds the http and https ports of
+// its node.
+var localPorts = []apiv1.ContainerPort{
+	{
+		Name:          "http-envoy-prom",
+		Protocol:      "TCP",
+		ContainerPort: 15090,
+	},
+	{
+		Name:          "http",
+		Protocol:      "TCP",
+		ContainerPort: 80,
+		HostPort:      80,
+	},
+	{
+		Name:          "https",
+		Protocol:      "TCP",
+		ContainerPort: 443,
+		HostPort:      443,
+	},
+}
+
+// ConvertTo converts this HigressGateway to the hub version v1beta1.
+func (src *HigressGateway) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.HigressGateway)
+	if err := convertObject(src, dst, []string{"spec", "env"}, []string{"spec", "netWorkGateway"}, []string{"spec", "local"}); err != nil {
+		return err
+	}
+
+	data, err := getConversionData(src)
+	if err != nil {
+		return err
+	}
+
+	dst.Spec.NetworkGateway = src.Spec.NetWorkGateway
+	dst.Spec.Env = restoreEnvVars(toEnvVars(src.Spec.Env), data.Env["spec.env"])
+	data.Env = nil
+
+	// a local gateway ignores its resources and binds the ports of the node
+	data.Local, data.Resources, data.LocalPorts = src.Spec.Local, nil, false
+	if src.Spec.Local {
+		data.Resources, dst.Spec.Resources = src.Spec.Resources, nil
+		if len(dst.Spec.Ports) == 0 {
+			data.LocalPorts, dst.Spec.Ports = true, append([]apiv1.ContainerPort(nil), localPorts...)
+		}
+	}
+
+	return setConversionData(dst, data)
+}
+
+// ConvertFrom converts the hub version v1beta1 to this HigressGateway.
+func (dst *HigressGateway) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.HigressGateway)
+	if err := convertObject(src, dst, []string{"spec", "env"}, []string{"spec", "networkGateway"}); err != nil {
+		return err
+	}
+
+	data, err := getConversionData(src)
+	if err != nil {
+		return err
+	}
+
+	dst.Spec.NetWorkGateway = src.Spec.NetworkGateway
+	env, valueFrom := fromEnvVars(src.Spec.Env)
+	dst.Spec.Env = env
+	data.Env = nil
+	if len(valueFrom) > 0 {
+		data.Env = map[string][]apiv1.EnvVar{"spec.env": valueFrom}
+	}
+
+	if data.Local {
+		dst.Spec.Local = true
+		if dst.Spec.Resources == nil {
+			dst.Spec.Resources = data.Resources
+		}
+		if data.LocalPorts && equality.Semantic.DeepEqual(dst.Spec.Ports, localPorts) {
+			dst.Spec.Ports = nil
+		}
+	}
+	data.Local, data.Resources, data.LocalPorts = false, nil, false
+
+	return setConversionData(dst, data)
+}
@@ -42,15 +42,49 @@ type HigressGatewaySpec struct {
 	VolumeWasmPlugins []string `json:"volumeWasmPlugins"`
 	// +kubebuilder:validation:Optional
 	HostNetwork bool `json:"hostNetwork"`
+	// HighAvailability spreads the gateway pods across zones and nodes with topology spread
+	// constraints on the selector labels, unless topologySpreadConstraints sets the same topology
+	// key. The pods are still scheduled if they can't be spread.
+	// +kubebuilder:validation:Optional
+	HighAvailability bool `json:"highAvailability"`
+	// ControllerRef binds the gateway to a HigressController, which may run in another namespace.
+	// The discovery address, the gateway selector and the trust settings are taken from it.
+	// +kubebuilder:validation:Optional
+	// +nullable
+	ControllerRef *ControllerReference `json:"controllerRef,omitempty"`
+}
+
+// ControllerReference points to a HigressController.
+type ControllerReference struct {
+	Name string `json:"name"`
+	// Namespace defaults to the namespace of the gateway.
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // HigressGatewayStatus defines the observed state of HigressGateway
 type HigressGatewayStatus struct {
-	Deployed bool `json:"deployed"`
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Inventory lists the objects applied for the instance, the ones that are no longer
+	// rendered from the spec are deleted.
+	// +kubebuilder:validation:Optional
+	Inventory []ResourceRef `json:"inventory,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +nullable
+	AutoScaling *AutoScalingStatus `json:"autoScaling,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+//+kubebuilder:deprecatedversion:warning="operator.higress.io/v1alpha1 HigressGateway is deprecated, use operator.higress.io/v1beta1"
 
 // HigressGateway is the Schema for the higressgateways API
 type HigressGateway struct {
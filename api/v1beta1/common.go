@@ -0,0 +1,272 @@
+package v1beta1
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Condition types reported in the status of HigressGateway, HigressController and HigressConsole.
+const (
+	// ConditionReady is true when all desired replicas of the managed Deployment are updated and available.
+	ConditionReady = "Ready"
+	// ConditionProgressing is true while the managed Deployment is rolling out.
+	ConditionProgressing = "Progressing"
+	// ConditionDegraded is true when the rollout of the managed Deployment is failing.
+	ConditionDegraded = "Degraded"
+	// ConditionReconcileError is true when the last reconcile failed at one of its steps.
+	ConditionReconcileError = "ReconcileError"
+	// ConditionFieldConflict is true when the last reconcile rendered fields owned by other field managers.
+	ConditionFieldConflict = "FieldConflict"
+	// ConditionGatewayConfigAvailable is true when the mesh config of the gateway of a
+	// HigressController exists, the controller is only deployed once it does.
+	ConditionGatewayConfigAvailable = "GatewayConfigAvailable"
+	// ConditionIngressClassConflict is true when an older HigressController serves the ingress class
+	// of a HigressController, which is then not reconciled.
+	ConditionIngressClassConflict = "IngressClassConflict"
+	// ConditionCRDsSkipped is true when no CRDs are embedded for the Higress release of the image of
+	// a HigressController, whose CRDs then have to be installed in another way.
+	ConditionCRDsSkipped = "CRDsSkipped"
+)
+
+// +k8s:deepcopy-gen=true
+
+// ResourceRef identifies an object applied by the operator for an instance.
+type ResourceRef struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// +k8s:deepcopy-gen=true
+
+type CRDCommonFields struct {
+	// +kubebuilder:validation:Optional
+	// +nullable
+	Replicas *int32 `json:"replicas,omitempty"`
+	// +kubebuilder:validation:Optional
+	SelectorLabels map[string]string `json:"selectorLabels"`
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector"`
+	// +kubebuilder:validation:Optional
+	// +nullable
+	Affinity *apiv1.Affinity `json:"affinity"`
+	// +kubebuilder:validation:Optional
+	Toleration []apiv1.Toleration `json:"toleration"`
+	// +kubebuilder:validation:Optional
+	TopologySpreadConstraints []apiv1.TopologySpreadConstraint `json:"topologySpreadConstraints"`
+	// +kubebuilder:validation:Optional
+	PriorityClassName string `json:"priorityClassName"`
+	// +kubebuilder:validation:Optional
+	SchedulerName string `json:"schedulerName"`
+	// +kubebuilder:validation:Optional
+	// +nullable
+	RuntimeClassName *string `json:"runtimeClassName"`
+	// +kubebuilder:validation:Optional
+	// +nullable
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds"`
+	// +kubebuilder:validation:Optional
+	// +nullable
+	Service *Service `json:"service"`
+	// +kubebuilder:validation:Optional
+	RBAC *RBAC `json:"rbac"`
+	// +kubebuilder:validation:Optional
+	ServiceAccount *ServiceAccount `json:"serviceAccount"`
+	// +kubebuilder:validation:Optional
+	// +nullable
+	AutoScaling *AutoScaling `json:"autoScaling"`
+	// PodDisruptionBudget limits the pods that voluntary disruptions, such as node drains, may
+	// evict at once.
+	// +kubebuilder:validation:Optional
+	// +nullable
+	PodDisruptionBudget *PodDisruptionBudget `json:"podDisruptionBudget"`
+	// +kubebuilder:validation:Optional
+	// +nullable
+	PodSecurityContext *apiv1.PodSecurityContext `json:"podSecurityContext"`
+
+	// +kubebuilder:validation:Optional
+	EnableStatus bool `json:"enableStatus"`
+	// +kubebuilder:validation:Optional
+	EnableHigressIstio bool `json:"enableHigressIstio"`
+	// +kubebuilder:validation:Optional
+	EnableIstioAPI bool `json:"enableIstioAPI"`
+	// +kubebuilder:validation:Optional
+	IstioNamespace string `json:"istioNamespace"`
+	// +kubebuilder:validation:Optional
+	Revision string `json:"revision"`
+	// +kubebuilder:validation:Optional
+	// +nullable
+	Istiod *Istio `json:"istiod"`
+	// +kubebuilder:validation:Optional
+	// +nullable
+	MultiCluster *MultiCluster `json:"multiCluster"`
+	// JwtPolicy is the policy of the tokens that the proxies authenticate with. Defaults to
+	// third-party-jwt, first-party-jwt is only needed by clusters without projected tokens.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=third-party-jwt;first-party-jwt
+	// +kubebuilder:default=third-party-jwt
+	JwtPolicy string `json:"jwtPolicy"`
+	// DriftPolicy decides what happens to managed objects that were changed outside the operator,
+	// Enforce reverts the changes and ReportOnly only reports them. Defaults to Enforce.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Enforce;ReportOnly
+	// +kubebuilder:default=Enforce
+	DriftPolicy DriftPolicy `json:"driftPolicy"`
+	// DeletionPolicy decides what happens to the managed objects when the CR is deleted, Delete
+	// removes them and Retain keeps them running without the CR. Defaults to Delete.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy"`
+}
+
+type DriftPolicy string
+
+const (
+	DriftPolicyEnforce    DriftPolicy = "Enforce"
+	DriftPolicyReportOnly DriftPolicy = "ReportOnly"
+)
+
+type DeletionPolicy string
+
+const (
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+)
+
+// +k8s:deepcopy-gen=true
+
+type ContainerCommonFields struct {
+	// +kubebuilder:validation:Optional
+	Name string `json:"name"`
+	// +kubebuilder:validation:Optional
+	Annotations map[string]string `json:"annotations"`
+	Image       Image             `json:"image"`
+	// +kubebuilder:validation:Optional
+	ImagePullSecrets []apiv1.LocalObjectReference `json:"imagePullSecrets"`
+	// Env is appended to the environment variables set by the operator.
+	// +kubebuilder:validation:Optional
+	Env []apiv1.EnvVar `json:"env"`
+	// +kubebuilder:validation:Optional
+	ReadinessProbe *apiv1.Probe `json:"readinessProbe"`
+	// +kubebuilder:validation:Optional
+	Ports []apiv1.ContainerPort `json:"ports"`
+	// +kubebuilder:validation:Optional
+	Resources *apiv1.ResourceRequirements `json:"resources"`
+	// +kubebuilder:validation:Optional
+	SecurityContext *apiv1.SecurityContext `json:"securityContext"`
+	// +kubebuilder:validation:Optional
+	LogLevel string `json:"logLevel"`
+	// +kubebuilder:validation:Optional
+	LogAsJson bool `json:"logAsJson"`
+}
+
+// +k8s:deepcopy-gen=true
+
+type Image struct {
+	Repository string `json:"repository"`
+	// Tag is the tag of the image, such as 1.3.0, a digest, such as sha256:<hex>, or both, such as
+	// 1.3.0@sha256:<hex>.
+	Tag string `json:"tag"`
+	// +kubebuilder:validation:Enum="";Always;Never;IfNotPresent
+	ImagePullPolicy apiv1.PullPolicy `json:"imagePullPolicy"`
+}
+
+// +k8s:deepcopy-gen=true
+
+type ServiceAccount struct {
+	Enable bool `json:"enable"`
+	// +kubebuilder:validation:Optional
+	Name string `json:"name"`
+	// +kubebuilder:validation:Optional
+	Annotations map[string]string `json:"annotations"`
+}
+
+// +k8s:deepcopy-gen=true
+
+type AutoScaling struct {
+	Enable      bool   `json:"enable"`
+	MinReplicas *int32 `json:"minReplicas"`
+	MaxReplicas int32  `json:"maxReplicas"`
+	// +kubebuilder:validation:Optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage"`
+	// +kubebuilder:validation:Optional
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage"`
+	// Metrics are appended to the cpu and memory targets, e.g. a Pods metric on the
+	// envoy_server_downstream_cx_active statistic scraped from port 15090.
+	// +kubebuilder:validation:Optional
+	Metrics []autoscalingv2.MetricSpec `json:"metrics"`
+	// Behavior configures the scale-up and scale-down policies, it requires autoscaling/v2beta2 or newer.
+	// +kubebuilder:validation:Optional
+	// +nullable
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// PodDisruptionBudget configures the PodDisruptionBudget of the pods of an instance. At most one
+// of minAvailable and maxUnavailable can be set, the budget lets one pod be evicted at a time if none is.
+type PodDisruptionBudget struct {
+	Enable bool `json:"enable"`
+	// +kubebuilder:validation:Optional
+	// +nullable
+	MinAvailable *intstr.IntOrString `json:"minAvailable"`
+	// +kubebuilder:validation:Optional
+	// +nullable
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable"`
+	// UnhealthyPodEvictionPolicy decides when unhealthy pods may be evicted, IfHealthyBudget only
+	// evicts them while the budget is met and AlwaysAllow evicts them regardless. Defaults to the
+	// policy of the cluster.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum="";IfHealthyBudget;AlwaysAllow
+	UnhealthyPodEvictionPolicy string `json:"unhealthyPodEvictionPolicy"`
+}
+
+// +k8s:deepcopy-gen=true
+
+type AutoScalingStatus struct {
+	// CurrentReplicas is the number of replicas last observed by the autoscaler.
+	CurrentReplicas int32 `json:"currentReplicas"`
+	// DesiredReplicas is the number of replicas last calculated by the autoscaler.
+	DesiredReplicas int32 `json:"desiredReplicas"`
+	// +kubebuilder:validation:Optional
+	// +nullable
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+type RBAC struct {
+	Enable bool `json:"enable"`
+}
+
+// +k8s:deepcopy-gen=true
+
+type Istio struct {
+	EnableAnalysis bool `json:"enableAnalysis"`
+}
+
+// +k8s:deepcopy-gen=true
+
+type MultiCluster struct {
+	Enable      bool   `json:"enable"`
+	ClusterName string `json:"clusterName"`
+}
+
+// +k8s:deepcopy-gen=true
+
+type Service struct {
+	Type  string              `json:"type"`
+	Ports []apiv1.ServicePort `json:"ports"`
+	// +kubebuilder:validation:Optional
+	Annotations map[string]string `json:"annotations"`
+	// +kubebuilder:validation:Optional
+	LoadBalancerIP string `json:"loadBalancerIP"`
+	// +kubebuilder:validation:Optional
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges"`
+	// +kubebuilder:validation:Optional
+	ExternalTrafficPolicy string `json:"externalTrafficPolicy"`
+}
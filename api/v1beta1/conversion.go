@@ -0,0 +1,9 @@
+package v1beta1
+
+// v1beta1 is the hub of the conversions, the other versions convert to and from it.
+
+// Hub marks HigressGateway as a conversion hub.
+func (*HigressGateway) Hub() {}
+
+// Hub marks HigressController as a conversion hub.
+func (*HigressController) Hub() {}
@@ -0,0 +1,210 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HigressSpec defines the desired state of Higress, a complete stack of a HigressController, a
+// HigressGateway and optionally a HigressConsole. The settings shared by the components are set
+// once and propagated to them.
+type HigressSpec struct {
+	// Hub is the registry of the images of the components.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=higress-registry.cn-hangzhou.cr.aliyuncs.com/higress
+	Hub string `json:"hub"`
+	// Tag is the Higress release of the images of the components.
+	Tag string `json:"tag"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum="";Always;Never;IfNotPresent
+	ImagePullPolicy apiv1.PullPolicy `json:"imagePullPolicy"`
+	// +kubebuilder:validation:Optional
+	ImagePullSecrets []apiv1.LocalObjectReference `json:"imagePullSecrets"`
+	// JwtPolicy is the policy of the tokens that the proxies authenticate with. Defaults to
+	// third-party-jwt.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=third-party-jwt;first-party-jwt
+	// +kubebuilder:default=third-party-jwt
+	JwtPolicy string `json:"jwtPolicy"`
+	// +kubebuilder:validation:Optional
+	Revision string `json:"revision"`
+	// IngressClass is the class of the ingresses served by the stack. Defaults to higress.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=higress
+	IngressClass string `json:"ingressClass"`
+	// WatchNamespace is the namespace watched by the controller, all namespaces if empty.
+	// +kubebuilder:validation:Optional
+	WatchNamespace string `json:"watchNamespace"`
+	// +kubebuilder:validation:Optional
+	// +nullable
+	GatewayAPI *GatewayAPI `json:"gatewayAPI,omitempty"`
+	// DriftPolicy is the drift policy of the components. Defaults to Enforce.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Enforce;ReportOnly
+	// +kubebuilder:default=Enforce
+	DriftPolicy DriftPolicy `json:"driftPolicy"`
+	// DeletionPolicy is the deletion policy of the components. Defaults to Delete.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy"`
+
+	// +kubebuilder:validation:Optional
+	Controller HigressComponent `json:"controller"`
+	// +kubebuilder:validation:Optional
+	Pilot HigressContainer `json:"pilot"`
+	// +kubebuilder:validation:Optional
+	Gateway HigressGatewayComponent `json:"gateway"`
+	// Console deploys the Higress console for the stack if set.
+	// +kubebuilder:validation:Optional
+	// +nullable
+	Console *HigressConsoleComponent `json:"console,omitempty"`
+}
+
+// HigressContainer holds the settings of a container that are not shared with the other components.
+type HigressContainer struct {
+	// Tag overrides the tag of the image of the container.
+	// +kubebuilder:validation:Optional
+	Tag string `json:"tag,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +nullable
+	Resources *apiv1.ResourceRequirements `json:"resources,omitempty"`
+	// Env is appended to the environment variables set by the operator.
+	// +kubebuilder:validation:Optional
+	Env []apiv1.EnvVar `json:"env,omitempty"`
+}
+
+// HigressComponent holds the settings of a component that are not shared with the other components.
+type HigressComponent struct {
+	HigressContainer `json:",inline"`
+
+	// +kubebuilder:validation:Optional
+	// +nullable
+	Replicas *int32 `json:"replicas,omitempty"`
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +nullable
+	Affinity *apiv1.Affinity `json:"affinity,omitempty"`
+	// +kubebuilder:validation:Optional
+	Toleration []apiv1.Toleration `json:"toleration,omitempty"`
+	// +kubebuilder:validation:Optional
+	TopologySpreadConstraints []apiv1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// +kubebuilder:validation:Optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// +kubebuilder:validation:Optional
+	SchedulerName string `json:"schedulerName,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +nullable
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +nullable
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+	// PodDisruptionBudget is not supported by the console.
+	// +kubebuilder:validation:Optional
+	// +nullable
+	PodDisruptionBudget *PodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+}
+
+type HigressGatewayComponent struct {
+	HigressComponent `json:",inline"`
+
+	// +kubebuilder:validation:Optional
+	// +nullable
+	Service *Service `json:"service,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +nullable
+	AutoScaling *AutoScaling `json:"autoScaling,omitempty"`
+	// +kubebuilder:validation:Optional
+	HostNetwork bool `json:"hostNetwork,omitempty"`
+	// HighAvailability spreads the gateway pods across zones and nodes.
+	// +kubebuilder:validation:Optional
+	HighAvailability bool `json:"highAvailability,omitempty"`
+	// Ports are the ports of the gateway container, e.g. with the hostPort of a gateway that binds
+	// the ports of its node.
+	// +kubebuilder:validation:Optional
+	Ports []apiv1.ContainerPort `json:"ports,omitempty"`
+}
+
+type HigressConsoleComponent struct {
+	HigressComponent `json:",inline"`
+
+	// +kubebuilder:validation:Optional
+	AdminUsername string `json:"adminUsername,omitempty"`
+	// Config is the content of the config ConfigMap of the console.
+	// +kubebuilder:validation:Optional
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// ComponentStatus is the readiness of a component of a Higress stack.
+type ComponentStatus struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Ready is the status of the Ready condition of the component, Unknown until the component
+	// has reconciled its current spec.
+	Ready metav1.ConditionStatus `json:"ready"`
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+// HigressStatus defines the observed state of Higress
+type HigressStatus struct {
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Inventory lists the objects applied for the instance, the ones that are no longer
+	// rendered from the spec are deleted.
+	// +kubebuilder:validation:Optional
+	Inventory []ResourceRef `json:"inventory,omitempty"`
+	// +kubebuilder:validation:Optional
+	Components []ComponentStatus `json:"components,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Tag",type=string,JSONPath=`.spec.tag`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+//+kubebuilder:storageversion
+
+// Higress is the Schema for the higresses API
+type Higress struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HigressSpec   `json:"spec,omitempty"`
+	Status HigressStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// HigressList contains a list of Higress
+type HigressList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Higress `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Higress{}, &HigressList{})
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HigressConsoleSpec defines the desired state of HigressConsole
+type HigressConsoleSpec struct {
+	CRDCommonFields       `json:",inline"`
+	ContainerCommonFields `json:",inline"`
+
+	// ControllerRef points to the HigressController whose ingress class and watched namespace are
+	// administered by the console. Defaults to the controller in the namespace of the console.
+	// +kubebuilder:validation:Optional
+	// +nullable
+	ControllerRef *ControllerReference `json:"controllerRef,omitempty"`
+	// AdminUsername is the name of the administrator, the password is generated once and kept in
+	// the admin Secret. Defaults to admin.
+	// +kubebuilder:validation:Optional
+	AdminUsername string `json:"adminUsername"`
+	// Config is the content of the config ConfigMap of the console.
+	// +kubebuilder:validation:Optional
+	Config map[string]string `json:"config"`
+}
+
+// HigressConsoleStatus defines the observed state of HigressConsole
+type HigressConsoleStatus struct {
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Inventory lists the objects applied for the instance, the ones that are no longer
+	// rendered from the spec are deleted.
+	// +kubebuilder:validation:Optional
+	Inventory []ResourceRef `json:"inventory,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+//+kubebuilder:storageversion
+
+// HigressConsole is the Schema for the higressconsoles API
+type HigressConsole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HigressConsoleSpec   `json:"spec,omitempty"`
+	Status HigressConsoleStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// HigressConsoleList contains a list of HigressConsole
+type HigressConsoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HigressConsole `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&HigressConsole{}, &HigressConsoleList{})
+}
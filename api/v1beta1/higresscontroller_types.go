@@ -0,0 +1,143 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HigressControllerSpec defines the desired state of HigressController
+type HigressControllerSpec struct {
+	CRDCommonFields `json:",inline"`
+
+	Controller ControllerSpec `json:"controller"`
+	Pilot      PilotSpec      `json:"pilot"`
+	// CRDInstallPolicy decides how the Higress CRDs are installed, Install creates and upgrades them,
+	// UpgradeOnly only upgrades the existing ones and Skip leaves them alone. Defaults to Install.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Install;Skip;UpgradeOnly
+	// +kubebuilder:default=Install
+	CRDInstallPolicy CRDInstallPolicy `json:"crdInstallPolicy"`
+	// GatewayAPI installs the Kubernetes Gateway API CRDs and enables the Gateway API support of
+	// the controller.
+	// +kubebuilder:validation:Optional
+	// +nullable
+	GatewayAPI *GatewayAPI `json:"gatewayAPI,omitempty"`
+}
+
+type CRDInstallPolicy string
+
+const (
+	CRDInstallPolicyInstall     CRDInstallPolicy = "Install"
+	CRDInstallPolicySkip        CRDInstallPolicy = "Skip"
+	CRDInstallPolicyUpgradeOnly CRDInstallPolicy = "UpgradeOnly"
+)
+
+type GatewayAPI struct {
+	// +kubebuilder:validation:Optional
+	Enable bool `json:"enable"`
+	// Version is the Gateway API release of the CRDs, such as v1.0.0. Defaults to the newest
+	// release embedded in the operator.
+	// +kubebuilder:validation:Optional
+	Version string `json:"version"`
+	// Channel is the release channel of the CRDs. Defaults to standard.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=standard;experimental
+	// +kubebuilder:default=standard
+	Channel GatewayAPIChannel `json:"channel"`
+}
+
+type GatewayAPIChannel string
+
+const (
+	GatewayAPIChannelStandard     GatewayAPIChannel = "standard"
+	GatewayAPIChannelExperimental GatewayAPIChannel = "experimental"
+)
+
+// HigressControllerStatus defines the observed state of HigressController
+type HigressControllerStatus struct {
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Inventory lists the objects applied for the instance, the ones that are no longer
+	// rendered from the spec are deleted.
+	// +kubebuilder:validation:Optional
+	Inventory []ResourceRef `json:"inventory,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+//+kubebuilder:storageversion
+
+// HigressController is the Schema for the higresscontrollers API
+type HigressController struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HigressControllerSpec   `json:"spec,omitempty"`
+	Status HigressControllerStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// HigressControllerList contains a list of HigressController
+type HigressControllerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HigressController `json:"items"`
+}
+
+type ControllerSpec struct {
+	ContainerCommonFields `json:",inline"`
+
+	GatewayName string `json:"gatewayName"`
+	// IngressClass is the name of the IngressClass and GatewayClass created for the controller.
+	IngressClass string `json:"ingressClass"`
+	// IsDefaultClass marks the IngressClass as the default class of the cluster.
+	// +kubebuilder:validation:Optional
+	IsDefaultClass bool `json:"isDefaultClass"`
+	// WatchNamespace is the namespace watched by the controller, all namespaces if empty.
+	// +kubebuilder:validation:Optional
+	WatchNamespace string `json:"watchNamespace"`
+	SDSTokenAud    string `json:"sdsTokenAud"`
+}
+
+type PilotSpec struct {
+	ContainerCommonFields `json:",inline"`
+
+	// +kubebuilder:validation:Optional
+	TraceSampling string `json:"traceSampling"`
+	// +kubebuilder:validation:Optional
+	JwksResolveExtraRootCA string `json:"jwksResolveExtraRootCA"`
+	// +kubebuilder:validation:Optional
+	Plugins                           []string `json:"plugins"`
+	KeepaliveMaxServerConnectionAge   string   `json:"keepaliveMaxServerConnectionAge"`
+	ClusterDomain                     string   `json:"clusterDomain"`
+	OneNamespace                      bool     `json:"oneNamespace"`
+	EnableProtocolSniffingForOutbound bool     `json:"enableProtocolSniffingForOutbound"`
+	EnableProtocolSniffingForInbound  bool     `json:"enableProtocolSniffingForInbound"`
+}
+
+func init() {
+	SchemeBuilder.Register(&HigressController{}, &HigressControllerList{})
+}
@@ -29,10 +29,17 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
 
 	operatorv1alpha1 "github.com/alibaba/higress/higress-operator/api/v1alpha1"
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
+	"github.com/alibaba/higress/higress-operator/internal/controller"
+	"github.com/alibaba/higress/higress-operator/internal/controller/higress"
+	"github.com/alibaba/higress/higress-operator/internal/controller/higressconsole"
 	"github.com/alibaba/higress/higress-operator/internal/controller/higresscontroller"
 	"github.com/alibaba/higress/higress-operator/internal/controller/higressgateway"
+	webhookv1beta1 "github.com/alibaba/higress/higress-operator/internal/webhook/v1beta1"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -45,6 +52,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(operatorv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(operatorv1beta1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -90,22 +98,73 @@ func main() {
 	}
 
 	if err = (&higresscontroller.HigressControllerReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Config: mgr.GetConfig(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Config:   mgr.GetConfig(),
+		Recorder: mgr.GetEventRecorderFor("higresscontroller-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "HigressController")
 		os.Exit(1)
 	}
 	if err = (&higressgateway.HigressGatewayReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Config:   mgr.GetConfig(),
+		Recorder: mgr.GetEventRecorderFor("higressgateway-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "HigressGateway")
 		os.Exit(1)
 	}
+	if err = (&higressconsole.HigressConsoleReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("higressconsole-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "HigressConsole")
+		os.Exit(1)
+	}
+	if err = (&higress.HigressReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("higress-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Higress")
+		os.Exit(1)
+	}
+	// the API server converts the objects stored in v1alpha1 with the conversion webhook, so that it
+	// is served even if the admission webhooks are disabled
+	mgr.GetWebhookServer().Register("/convert", conversion.NewWebhookHandler(mgr.GetScheme()))
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = webhookv1beta1.SetupHigressControllerWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "HigressController")
+			os.Exit(1)
+		}
+		if err = webhookv1beta1.SetupHigressGatewayWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "HigressGateway")
+			os.Exit(1)
+		}
+		if err = webhookv1beta1.SetupHigressConsoleWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "HigressConsole")
+			os.Exit(1)
+		}
+		if err = webhookv1beta1.SetupHigressWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Higress")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
+	if err = mgr.Add(&controller.StorageMigration{
+		Client: mgr.GetClient(),
+		Reader: mgr.GetAPIReader(),
+		Config: mgr.GetConfig(),
+	}); err != nil {
+		setupLog.Error(err, "unable to set up the storage migration")
+		os.Exit(1)
+	}
+
+	metrics.Registry.MustRegister(controller.NewInstanceCollector(mgr.GetCache()))
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
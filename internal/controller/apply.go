@@ -0,0 +1,361 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+const (
+	// FieldManager is the field manager of the fields applied by the operator.
+	FieldManager = "higress-operator"
+	// DriftCheckInterval is the interval of the reconciles that check the managed objects for drifts.
+	DriftCheckInterval = 10 * time.Minute
+)
+
+// FieldConflict is a conflict with other field managers over the fields of an applied object.
+type FieldConflict struct {
+	Kind string
+	Key  client.ObjectKey
+	// Fields are the paths of the conflicting fields.
+	Fields []string
+	// Forced is true if the fields were taken over to revert a drift, otherwise they were left to
+	// the other managers.
+	Forced bool
+}
+
+func (c FieldConflict) String() string {
+	if c.Forced {
+		return fmt.Sprintf("{%s:%s}: the fields %s owned by other field managers were taken over",
+			c.Kind, c.Key, strings.Join(c.Fields, ", "))
+	}
+	return fmt.Sprintf("{%s:%s}: the fields %s are owned by other field managers and were not applied",
+		c.Kind, c.Key, strings.Join(c.Fields, ", "))
+}
+
+// Drift is a difference between a live object and the object rendered from the spec of its CR.
+type Drift struct {
+	Kind string
+	Key  client.ObjectKey
+	// Enforced is true if the rendered object was applied again.
+	Enforced bool
+}
+
+func (d Drift) String() string {
+	if d.Enforced {
+		return fmt.Sprintf("{%s:%s} drifted from the spec and was reverted", d.Kind, d.Key)
+	}
+	return fmt.Sprintf("{%s:%s} drifted from the spec, the change is kept by the ReportOnly policy", d.Kind, d.Key)
+}
+
+// ApplyOptions configure the Apply calls of a reconcile.
+type ApplyOptions struct {
+	// Owner is the CR that the events of the managed objects are recorded on.
+	Owner    client.Object
+	Recorder record.EventRecorder
+	// DriftPolicy decides whether drifted objects are applied again.
+	DriftPolicy v1beta1.DriftPolicy
+	// DetectDrift should only be set once the operator has applied the current spec of the CR,
+	// otherwise every change of the spec would be reported as a drift.
+	DetectDrift bool
+}
+
+type applyStateKey struct{}
+
+type applyState struct {
+	ApplyOptions
+	conflicts []FieldConflict
+	drifts    []Drift
+	applied   []v1beta1.ResourceRef
+}
+
+// WithApplyOptions returns a copy of ctx in which Apply uses opts and records the field conflicts
+// and drifts of a reconcile.
+func WithApplyOptions(ctx context.Context, opts ApplyOptions) context.Context {
+	return context.WithValue(ctx, applyStateKey{}, &applyState{ApplyOptions: opts})
+}
+
+func getApplyState(ctx context.Context) *applyState {
+	if state, ok := ctx.Value(applyStateKey{}).(*applyState); ok {
+		return state
+	}
+	return &applyState{}
+}
+
+func (s *applyState) event(eventType, reason, messageFmt string, args ...interface{}) {
+	if s.Recorder != nil && s.Owner != nil {
+		s.Recorder.Eventf(s.Owner, eventType, reason, messageFmt, args...)
+	}
+}
+
+// FieldConflicts returns the field conflicts recorded in ctx.
+func FieldConflicts(ctx context.Context) []FieldConflict {
+	return append([]FieldConflict(nil), getApplyState(ctx).conflicts...)
+}
+
+// Drifts returns the drifts recorded in ctx.
+func Drifts(ctx context.Context) []Drift {
+	return append([]Drift(nil), getApplyState(ctx).drifts...)
+}
+
+// Apply server-side applies object with FieldManager, so that the operator only owns the fields
+// it renders and the fields set by other controllers are kept. If some of the fields are owned
+// by other managers, the conflict is recorded in ctx and the fields are left to them, unless
+// the live object has drifted and the drift policy in ctx is Enforce, which takes them over.
+// If the live object has drifted from object and the drift policy in ctx is ReportOnly, the
+// drift is recorded and the live object is left alone.
+// On success object is updated with the state returned by the api server.
+func Apply(ctx context.Context, cli client.Client, kind string, object client.Object, logger logr.Logger) error {
+	return apply(ctx, cli, kind, object, false, logger)
+}
+
+// ApplySetFields applies object like Apply, but only with the fields of its spec that are set, so
+// that the operator doesn't own the unset ones. The fields left unset can be changed on the live
+// object by its users and are not reverted as drifts.
+func ApplySetFields(ctx context.Context, cli client.Client, kind string, object client.Object, logger logr.Logger) error {
+	return apply(ctx, cli, kind, object, true, logger)
+}
+
+func apply(ctx context.Context, cli client.Client, kind string, object client.Object, setFieldsOnly bool, logger logr.Logger) error {
+	key := client.ObjectKeyFromObject(object)
+	state := getApplyState(ctx)
+
+	obj, err := toApplyConfiguration(cli.Scheme(), object)
+	if err != nil {
+		return err
+	}
+	if setFieldsOnly {
+		if spec, ok := obj.Object["spec"].(map[string]interface{}); ok {
+			removeUnset(spec, reflect.ValueOf(object).Elem().FieldByName("Spec"))
+		}
+	}
+	if err = setInventoryLabels(cli, obj, state.Owner); err != nil {
+		return err
+	}
+	state.applied = append(state.applied, refOf(obj))
+
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(obj.GroupVersionKind())
+	if err = cli.Get(ctx, key, live); err != nil {
+		if !errors.IsNotFound(err) {
+			logger.Error(err, fmt.Sprintf("Failed to get object {%s:%s}", kind, key))
+			return err
+		}
+		live = nil
+	}
+
+	// a missing object is created whatever the drift policy is
+	enforced := false
+	if state.DetectDrift && live != nil {
+		diff, err := detectDrift(ctx, cli, live, obj)
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to detect the drift of object {%s:%s}", kind, key))
+			return err
+		}
+
+		if diff != "" {
+			drift := Drift{Kind: kind, Key: key, Enforced: state.DriftPolicy != v1beta1.DriftPolicyReportOnly}
+			logger.Info(fmt.Sprintf("the drift of object {%s:%s} is %v", kind, key, diff))
+			state.drifts = append(state.drifts, drift)
+			state.event(apiv1.EventTypeWarning, ReasonDriftDetected, drift.String())
+			DriftTotal.WithLabelValues(kind, key.Namespace, key.Name, string(state.DriftPolicy)).Inc()
+
+			if !drift.Enforced {
+				return fromUnstructured(live.Object, object)
+			}
+			enforced = true
+		}
+	}
+
+	err = cli.Patch(ctx, obj, client.Apply, client.FieldOwner(FieldManager))
+	if errors.IsConflict(err) {
+		conflict := FieldConflict{Kind: kind, Key: key, Fields: conflictingFields(err), Forced: enforced}
+		if len(conflict.Fields) == 0 {
+			logger.Error(err, fmt.Sprintf("Failed to apply object {%s:%s}", kind, key))
+			state.event(apiv1.EventTypeWarning, ReasonApplyFailed, "Failed to apply %s %s: %v", kind, key, err)
+			return err
+		}
+		state.conflicts = append(state.conflicts, conflict)
+		FieldConflictTotal.WithLabelValues(kind, key.Namespace, key.Name).Inc()
+
+		if conflict.Forced {
+			// only the fields of obj are taken over, which are the ones rendered by the operator
+			logger.Info(fmt.Sprintf("take over the conflicting fields of object {%s:%s}: %v", kind, key, err))
+			state.event(apiv1.EventTypeWarning, ReasonFieldsTakenOver, conflict.String())
+			err = cli.Patch(ctx, obj, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
+		} else {
+			logger.Info(fmt.Sprintf("leave the conflicting fields of object {%s:%s} to their managers: %v", kind, key, err))
+			state.event(apiv1.EventTypeWarning, ReasonFieldsYielded, conflict.String())
+			err = applyWithout(ctx, cli, obj, conflict.Fields)
+		}
+	}
+	if err != nil {
+		logger.Error(err, fmt.Sprintf("Failed to apply object {%s:%s}", kind, key))
+		state.event(apiv1.EventTypeWarning, ReasonApplyFailed, "Failed to apply %s %s: %v", kind, key, err)
+		return err
+	}
+
+	switch {
+	case live == nil:
+		logger.Info(fmt.Sprintf("apply object {%s:%s} : created", kind, key))
+		state.event(apiv1.EventTypeNormal, ReasonCreated, "Created %s %s", kind, key)
+	case live.GetResourceVersion() != obj.GetResourceVersion():
+		logger.Info(fmt.Sprintf("apply object {%s:%s} : updated", kind, key))
+		state.event(apiv1.EventTypeNormal, ReasonUpdated, "Updated %s %s", kind, key)
+	default:
+		logger.Info(fmt.Sprintf("apply object {%s:%s} : unchanged", kind, key))
+	}
+
+	return fromUnstructured(obj.Object, object)
+}
+
+// applyWithout applies obj without the given fields, so that they are left to their managers.
+func applyWithout(ctx context.Context, cli client.Client, obj *unstructured.Unstructured, fields []string) error {
+	yielded := obj.DeepCopy()
+	for _, field := range fields {
+		if err := removeField(yielded.Object, field); err != nil {
+			return err
+		}
+	}
+
+	if err := cli.Patch(ctx, yielded, client.Apply, client.FieldOwner(FieldManager)); err != nil {
+		return err
+	}
+	obj.Object = yielded.Object
+	return nil
+}
+
+// fromUnstructured copies content into object, which may itself be unstructured for the kinds that
+// are not part of the scheme.
+func fromUnstructured(content map[string]interface{}, object client.Object) error {
+	if u, ok := object.(*unstructured.Unstructured); ok {
+		u.Object = content
+		return nil
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(content, object)
+}
+
+// detectDrift returns the diff between the live object and the result of applying obj to it. The
+// apply is only a dry run, so the result includes the defaults of the api server and the fields of
+// other managers, and only the fields owned by the operator can differ.
+func detectDrift(ctx context.Context, cli client.Client, live, obj *unstructured.Unstructured) (string, error) {
+	desired := obj.DeepCopy()
+	if err := cli.Patch(ctx, desired, client.Apply, client.FieldOwner(FieldManager),
+		client.ForceOwnership, client.DryRunAll); err != nil {
+		return "", err
+	}
+
+	return cmp.Diff(volatileFieldsRemoved(live), volatileFieldsRemoved(desired)), nil
+}
+
+// volatileFieldsRemoved drops the fields that are changed by every write.
+func volatileFieldsRemoved(obj *unstructured.Unstructured) map[string]interface{} {
+	content := obj.DeepCopy().Object
+	unstructured.RemoveNestedField(content, "status")
+	unstructured.RemoveNestedField(content, "metadata", "managedFields")
+	unstructured.RemoveNestedField(content, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(content, "metadata", "generation")
+	return content
+}
+
+// toApplyConfiguration converts object to the unstructured form sent in an apply patch. The
+// status, the empty creationTimestamp and the unset fields, which the custom resources render as
+// nulls, are dropped, as they are never owned by the operator.
+func toApplyConfiguration(scheme *runtime.Scheme, object client.Object) (*unstructured.Unstructured, error) {
+	gvk, err := apiutil.GVKForObject(object, scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := &unstructured.Unstructured{Object: content}
+	obj.SetGroupVersionKind(gvk)
+	unstructured.RemoveNestedField(obj.Object, "status")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "creationTimestamp")
+	removeNulls(obj.Object)
+	return obj, nil
+}
+
+func removeNulls(content map[string]interface{}) {
+	for k, v := range content {
+		switch v := v.(type) {
+		case nil:
+			delete(content, k)
+		case map[string]interface{}:
+			removeNulls(v)
+		case []interface{}:
+			for _, item := range v {
+				if m, ok := item.(map[string]interface{}); ok {
+					removeNulls(m)
+				}
+			}
+		}
+	}
+}
+
+// removeUnset drops the fields of content that have their zero value in v, the struct that
+// content was converted from. The maps of v are kept whole, as their entries are all set.
+func removeUnset(content map[string]interface{}, v reflect.Value) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" && strings.Contains(opts, "inline") {
+			removeUnset(content, v.Field(i))
+			continue
+		}
+
+		value, ok := content[name]
+		if !ok {
+			continue
+		}
+		if v.Field(i).IsZero() {
+			delete(content, name)
+			continue
+		}
+		switch value := value.(type) {
+		case map[string]interface{}:
+			removeUnset(value, v.Field(i))
+		case []interface{}:
+			if v.Field(i).Kind() != reflect.Slice {
+				continue
+			}
+			for j, item := range value {
+				if m, ok := item.(map[string]interface{}); ok && j < v.Field(i).Len() {
+					removeUnset(m, v.Field(i).Index(j))
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+func TestRemoveUnset(t *testing.T) {
+	gateway := &v1beta1.HigressGateway{}
+	gateway.Spec.EnableStatus = true
+	gateway.Spec.NodeSelector = map[string]string{"pool": ""}
+	gateway.Spec.Image = v1beta1.Image{Repository: "higress/gateway", Tag: "1.3.0"}
+	gateway.Spec.Env = []apiv1.EnvVar{{Name: "A", Value: "1"}}
+	gateway.Spec.RollingMaxSurge = intstr.FromString("100%")
+	gateway.Spec.MeshConfig.TrustDomain = "cluster.local"
+	gateway.Spec.ControllerRef = &v1beta1.ControllerReference{Name: "higress-controller"}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(gateway)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := content["spec"].(map[string]interface{})
+	removeNulls(spec)
+	removeUnset(spec, reflect.ValueOf(gateway.Spec))
+
+	want := map[string]interface{}{
+		"enableStatus":    true,
+		"nodeSelector":    map[string]interface{}{"pool": ""},
+		"image":           map[string]interface{}{"repository": "higress/gateway", "tag": "1.3.0"},
+		"env":             []interface{}{map[string]interface{}{"name": "A", "value": "1"}},
+		"rollingMaxSurge": "100%",
+		"meshConfig":      map[string]interface{}{"trustDomain": "cluster.local"},
+		"controllerRef":   map[string]interface{}{"name": "higress-controller"},
+	}
+	if !reflect.DeepEqual(spec, want) {
+		t.Errorf("removeUnset() = %v, want %v", spec, want)
+	}
+}
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := v1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return scheme
+}
+
+func newOwner() *v1beta1.HigressGateway {
+	return &v1beta1.HigressGateway{ObjectMeta: metav1.ObjectMeta{Name: "edge", Namespace: "tenant", UID: "edge-uid"}}
+}
+
+// managedLabels returns the labels of an object applied for the owner name in namespace tenant.
+func managedLabels(name string) map[string]string {
+	return map[string]string{
+		LabelManagedBy:      ManagedByOperator,
+		LabelOwnerKind:      "HigressGateway",
+		LabelOwnerNamespace: "tenant",
+		LabelOwnerName:      name,
+	}
+}
+
+func newConfigMap(name string, labels map[string]string) *apiv1.ConfigMap {
+	return &apiv1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "tenant", Labels: labels}}
+}
+
+// newDriftClient returns a client with the ConfigMap live, whose dry run applies return the live
+// object if drifted is false and the applied object otherwise. The other applies are counted in
+// applies, as the fake client doesn't merge them.
+func newDriftClient(t *testing.T, live *apiv1.ConfigMap, drifted bool, applies *int) client.Client {
+	return fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(live).WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(ctx context.Context, cli client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			options := &client.PatchOptions{}
+			options.ApplyOptions(opts)
+			if len(options.DryRun) == 0 {
+				*applies++
+				return nil
+			}
+			if drifted {
+				return nil
+			}
+			return cli.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+		},
+	}).Build()
+}
+
+func TestApplyDrift(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       v1beta1.DriftPolicy
+		detectDrift  bool
+		drifted      bool
+		wantApplies  int
+		wantDrifts   []Drift
+		wantLiveData bool
+	}{
+		{
+			name:         "report only",
+			policy:       v1beta1.DriftPolicyReportOnly,
+			detectDrift:  true,
+			drifted:      true,
+			wantDrifts:   []Drift{{Kind: "ConfigMap", Key: client.ObjectKey{Namespace: "tenant", Name: "edge"}}},
+			wantLiveData: true,
+		},
+		{
+			name:        "enforce",
+			policy:      v1beta1.DriftPolicyEnforce,
+			detectDrift: true,
+			drifted:     true,
+			wantApplies: 1,
+			wantDrifts:  []Drift{{Kind: "ConfigMap", Key: client.ObjectKey{Namespace: "tenant", Name: "edge"}, Enforced: true}},
+		},
+		{
+			name:        "report only without a drift",
+			policy:      v1beta1.DriftPolicyReportOnly,
+			detectDrift: true,
+			wantApplies: 1,
+		},
+		{
+			// a changed spec is applied whatever the drift policy is
+			name:        "report only with a changed spec",
+			policy:      v1beta1.DriftPolicyReportOnly,
+			drifted:     true,
+			wantApplies: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			live := newConfigMap("edge", managedLabels("edge"))
+			live.Data = map[string]string{"mesh": "edited"}
+			applies := 0
+			cli := newDriftClient(t, live, tt.drifted, &applies)
+			recorder := record.NewFakeRecorder(10)
+			ctx := WithApplyOptions(context.Background(), ApplyOptions{
+				Owner:       newOwner(),
+				Recorder:    recorder,
+				DriftPolicy: tt.policy,
+				DetectDrift: tt.detectDrift,
+			})
+
+			cm := newConfigMap("edge", nil)
+			cm.Data = map[string]string{"mesh": "rendered"}
+			if err := Apply(ctx, cli, "ConfigMap", cm, logr.Discard()); err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+
+			if applies != tt.wantApplies {
+				t.Errorf("applies = %d, want %d", applies, tt.wantApplies)
+			}
+			if drifts := Drifts(ctx); !reflect.DeepEqual(drifts, tt.wantDrifts) {
+				t.Errorf("Drifts() = %v, want %v", drifts, tt.wantDrifts)
+			}
+			if got := cm.Data["mesh"] == "edited"; got != tt.wantLiveData {
+				t.Errorf("data = %v, want the live data %v", cm.Data, tt.wantLiveData)
+			}
+			driftEvent := false
+			for len(recorder.Events) > 0 {
+				if strings.Contains(<-recorder.Events, ReasonDriftDetected) {
+					driftEvent = true
+				}
+			}
+			if driftEvent != (len(tt.wantDrifts) > 0) {
+				t.Errorf("DriftDetected event = %v, want it only for a drift", driftEvent)
+			}
+		})
+	}
+}
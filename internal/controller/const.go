@@ -1,6 +1,27 @@
 package controller
 
+import "fmt"
+
 const (
 	HigressGatewayConfig = "higress-gateway-config"
 	DefaultNamespace     = "higress-system"
 )
+
+// GatewayConfigName returns the name of the mesh config ConfigMap of the HigressGateway named gateway.
+func GatewayConfigName(gateway string) string {
+	if gateway == "" {
+		return HigressGatewayConfig
+	}
+	return fmt.Sprintf("%s-config", gateway)
+}
+
+// LabelGatewayConfigOf marks the copies of the gateway config that the gateways of another namespace
+// write to the namespace of their controller, its value is the name of the controller.
+const LabelGatewayConfigOf = "operator.higress.io/gateway-config-of"
+
+// RemoteGatewayConfigName returns the name of the copy of the mesh config of the HigressGateway
+// namespace/gateway in the namespace of its controller, which the gateways of the same name in
+// different namespaces don't share.
+func RemoteGatewayConfigName(namespace, gateway string) string {
+	return GatewayConfigName(fmt.Sprintf("%s-%s", namespace, gateway))
+}
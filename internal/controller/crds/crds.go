@@ -0,0 +1,127 @@
+// Package crds embeds the CRD manifests of the supported Higress releases, one directory per
+// major.minor release.
+package crds
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+
+	apixv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// AnnotationRelease records the Higress release of the CRDs installed by the operator.
+const AnnotationRelease = "operator.higress.io/crd-release"
+
+const decoderBufferSize = 4096
+
+// ErrReleaseNotEmbedded is returned by Select for the releases older than the embedded ones.
+var ErrReleaseNotEmbedded = errors.New("no CRDs are embedded for the release")
+
+//go:embed */*.yaml
+var manifests embed.FS
+
+// Releases returns the releases with embedded CRDs, from the oldest to the newest.
+func Releases() ([]*version.Version, error) {
+	entries, err := manifests.ReadDir(".")
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []*version.Version
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		release, err := version.ParseGeneric(entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("invalid CRD release directory %v: %w", entry.Name(), err)
+		}
+		releases = append(releases, release)
+	}
+
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].LessThan(releases[j])
+	})
+	return releases, nil
+}
+
+// Select returns the newest release with embedded CRDs that is not newer than the Higress version
+// tag. Tags that are not versions, such as latest, select the newest release.
+func Select(tag string) (*version.Version, error) {
+	releases, err := Releases()
+	if err != nil {
+		return nil, err
+	}
+	if len(releases) == 0 {
+		return nil, fmt.Errorf("no CRDs are embedded")
+	}
+
+	v, err := version.ParseGeneric(tag)
+	if err != nil {
+		return releases[len(releases)-1], nil
+	}
+
+	for i := len(releases) - 1; i >= 0; i-- {
+		if v.AtLeast(releases[i]) {
+			return releases[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: Higress %v is older than the oldest embedded release %v", ErrReleaseNotEmbedded, tag, releases[0])
+}
+
+// Get returns the CRDs of release, annotated with the release.
+func Get(release *version.Version) ([]*apixv1.CustomResourceDefinition, error) {
+	dir := fmt.Sprintf("%d.%d", release.Major(), release.Minor())
+	files, err := manifests.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("no CRDs are embedded for release %v: %w", dir, err)
+	}
+
+	var crds []*apixv1.CustomResourceDefinition
+	for _, f := range files {
+		decoded, err := decode(manifests, path.Join(dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, crd := range decoded {
+			if crd.Annotations == nil {
+				crd.Annotations = map[string]string{}
+			}
+			crd.Annotations[AnnotationRelease] = dir
+		}
+		crds = append(crds, decoded...)
+	}
+
+	return crds, nil
+}
+
+// decode returns the CRDs of the manifest file in fsys, other kinds of objects are skipped.
+func decode(fsys embed.FS, file string) ([]*apixv1.CustomResourceDefinition, error) {
+	content, err := fsys.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var crds []*apixv1.CustomResourceDefinition
+	dec := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(content), decoderBufferSize)
+	for {
+		crd := &apixv1.CustomResourceDefinition{}
+		if err = dec.Decode(crd); err != nil {
+			if err != io.EOF {
+				return nil, fmt.Errorf("failed to parse the CRD manifest %v: %w", file, err)
+			}
+			return crds, nil
+		}
+		if crd.Kind == "CustomResourceDefinition" && crd.Name != "" {
+			crds = append(crds, crd)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package crds
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSelect(t *testing.T) {
+	for tag, want := range map[string]string{
+		"1.1.0":  "1.1",
+		"v1.1.1": "1.1",
+		"1.3.2":  "1.1",
+		"latest": "1.1",
+	} {
+		release, err := Select(tag)
+		if err != nil {
+			t.Fatalf("Select(%q) error = %v", tag, err)
+		}
+		if release.String() != want {
+			t.Errorf("Select(%q) = %v, want %v", tag, release, want)
+		}
+	}
+}
+
+func TestSelectOlderRelease(t *testing.T) {
+	if _, err := Select("1.0.9"); !errors.Is(err, ErrReleaseNotEmbedded) {
+		t.Errorf("Select(1.0.9) error = %v, want %v", err, ErrReleaseNotEmbedded)
+	}
+}
+
+func TestGet(t *testing.T) {
+	release, err := Select("1.1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	crds, err := Get(release)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(crds) == 0 {
+		t.Fatal("Get() returned no CRDs")
+	}
+	for _, crd := range crds {
+		if crd.Annotations[AnnotationRelease] != "1.1" {
+			t.Errorf("CRD %s has the release annotation %q, want 1.1", crd.Name, crd.Annotations[AnnotationRelease])
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package crds
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+
+	apixv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// Annotations set on the Gateway API CRDs by their bundles.
+const (
+	AnnotationGatewayAPIBundleVersion = "gateway.networking.k8s.io/bundle-version"
+	AnnotationGatewayAPIChannel       = "gateway.networking.k8s.io/channel"
+)
+
+const gatewayAPIDir = "gateway-api"
+
+//go:embed gateway-api
+var gatewayAPIManifests embed.FS
+
+// GatewayAPIVersions returns the versions of the embedded Gateway API bundles, from the oldest to
+// the newest.
+func GatewayAPIVersions() ([]string, error) {
+	entries, err := gatewayAPIManifests.ReadDir(gatewayAPIDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err = version.ParseGeneric(entry.Name()); err != nil {
+			return nil, fmt.Errorf("invalid Gateway API bundle directory %v: %w", entry.Name(), err)
+		}
+		versions = append(versions, entry.Name())
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return version.MustParseGeneric(versions[i]).LessThan(version.MustParseGeneric(versions[j]))
+	})
+	return versions, nil
+}
+
+// GetGatewayAPI returns the CRDs of the channel bundle of the Gateway API release bundleVersion,
+// or of the newest embedded release if bundleVersion is empty.
+func GetGatewayAPI(bundleVersion, channel string) ([]*apixv1.CustomResourceDefinition, error) {
+	versions, err := GatewayAPIVersions()
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("no Gateway API CRDs are embedded, the operator has to be built with make gateway-api-crds")
+	}
+
+	if bundleVersion == "" {
+		bundleVersion = versions[len(versions)-1]
+	}
+	crds, err := decode(gatewayAPIManifests, path.Join(gatewayAPIDir, bundleVersion, fmt.Sprintf("%s-install.yaml", channel)))
+	if err != nil {
+		return nil, fmt.Errorf("the %v Gateway API CRDs of %v are not embedded, the embedded releases are %v: %w",
+			channel, bundleVersion, versions, err)
+	}
+
+	return crds, nil
+}
@@ -0,0 +1,46 @@
+package crds
+
+import "testing"
+
+// The bundles are downloaded by make gateway-api-crds, which the build and test targets depend on.
+func TestGatewayAPIEmbedded(t *testing.T) {
+	versions, err := GatewayAPIVersions()
+	if err != nil {
+		t.Fatalf("GatewayAPIVersions() error = %v", err)
+	}
+	if len(versions) == 0 {
+		t.Fatal("no Gateway API CRDs are embedded, run make gateway-api-crds")
+	}
+
+	for _, v := range versions {
+		for _, channel := range []string{"standard", "experimental"} {
+			crds, err := GetGatewayAPI(v, channel)
+			if err != nil {
+				t.Fatalf("GetGatewayAPI(%s, %s) error = %v", v, channel, err)
+			}
+			if len(crds) == 0 {
+				t.Fatalf("GetGatewayAPI(%s, %s) returned no CRDs", v, channel)
+			}
+			for _, crd := range crds {
+				if crd.Annotations[AnnotationGatewayAPIBundleVersion] != v || crd.Annotations[AnnotationGatewayAPIChannel] != channel {
+					t.Errorf("CRD %s has the annotations %v, want the bundle %s of the %s channel", crd.Name, crd.Annotations, v, channel)
+				}
+			}
+		}
+	}
+
+	// an empty version selects the newest release
+	crds, err := GetGatewayAPI("", "standard")
+	if err != nil {
+		t.Fatalf("GetGatewayAPI() error = %v", err)
+	}
+	if newest := versions[len(versions)-1]; crds[0].Annotations[AnnotationGatewayAPIBundleVersion] != newest {
+		t.Errorf("GetGatewayAPI() selected %s, want %s", crds[0].Annotations[AnnotationGatewayAPIBundleVersion], newest)
+	}
+}
+
+func TestGetGatewayAPINotEmbedded(t *testing.T) {
+	if _, err := GetGatewayAPI("v0.1.0", "standard"); err == nil {
+		t.Error("GetGatewayAPI(v0.1.0) error = nil, want an error")
+	}
+}
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"context"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+// AnnotationDefaultsVersion records the version of the defaults that an instance was created with.
+// The defaults of newer operator releases get a new version, so that an upgrade doesn't change the
+// effective configuration of the existing instances.
+const AnnotationDefaultsVersion = "operator.higress.io/defaults-version"
+
+// InitialDefaultsVersion is the version of the defaults of the instances created before the
+// versions were recorded.
+const InitialDefaultsVersion = 1
+
+// DefaultsVersion returns the version of the defaults recorded on obj, or InitialDefaultsVersion if
+// none is recorded.
+func DefaultsVersion(obj client.Object) int {
+	v, err := strconv.Atoi(obj.GetAnnotations()[AnnotationDefaultsVersion])
+	if err != nil || v < InitialDefaultsVersion {
+		return InitialDefaultsVersion
+	}
+	return v
+}
+
+// SetDefaultsVersion records version as the version of the defaults of obj.
+func SetDefaultsVersion(obj client.Object, version int) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[AnnotationDefaultsVersion] = strconv.Itoa(version)
+	obj.SetAnnotations(annotations)
+}
+
+// RecordDefaultsVersion records the defaults version of an instance that the defaulting webhook
+// didn't admit, such as one created while the webhook was not deployed or with a generated name.
+// An instance that owns nothing yet is new and gets latest, the others were created before the
+// versions were recorded and keep the initial one. owned is the object that the instances of every
+// version are deployed with, only its name and namespace need to be set. It returns true if the
+// version was recorded, the instance then has to be updated.
+func RecordDefaultsVersion(ctx context.Context, cli client.Client, instance client.Object,
+	inventory []v1beta1.ResourceRef, owned client.Object, latest int) (bool, error) {
+	if _, ok := instance.GetAnnotations()[AnnotationDefaultsVersion]; ok {
+		return false, nil
+	}
+
+	version := latest
+	if len(inventory) > 0 {
+		version = InitialDefaultsVersion
+	} else if err := cli.Get(ctx, client.ObjectKeyFromObject(owned), owned); err == nil {
+		if metav1.IsControlledBy(owned, instance) {
+			version = InitialDefaultsVersion
+		}
+	} else if !errors.IsNotFound(err) {
+		return false, err
+	}
+
+	SetDefaultsVersion(instance, version)
+	return true, nil
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+func TestRecordDefaultsVersion(t *testing.T) {
+	const latest = 3
+	controlled := true
+
+	tests := []struct {
+		name         string
+		version      int
+		inventory    []v1beta1.ResourceRef
+		live         *appsv1.Deployment
+		wantRecorded bool
+		wantVersion  int
+	}{
+		{
+			name:        "recorded version",
+			version:     2,
+			wantVersion: 2,
+		},
+		{
+			name:         "new instance",
+			wantRecorded: true,
+			wantVersion:  latest,
+		},
+		{
+			name:         "instance with an inventory",
+			inventory:    []v1beta1.ResourceRef{{Kind: "ConfigMap", Namespace: "tenant", Name: "edge"}},
+			wantRecorded: true,
+			wantVersion:  InitialDefaultsVersion,
+		},
+		{
+			// the instances created before the inventory was recorded are known by their Deployment
+			name: "instance with a Deployment",
+			live: &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "edge", Namespace: "tenant",
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "operator.higress.io/v1beta1", Kind: "HigressGateway",
+					Name: "edge", UID: "edge-uid", Controller: &controlled}}}},
+			wantRecorded: true,
+			wantVersion:  InitialDefaultsVersion,
+		},
+		{
+			name:         "Deployment of another owner",
+			live:         &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "edge", Namespace: "tenant"}},
+			wantRecorded: true,
+			wantVersion:  latest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(newScheme(t))
+			if tt.live != nil {
+				builder = builder.WithObjects(tt.live)
+			}
+			cli := builder.Build()
+
+			instance := newOwner()
+			if tt.version > 0 {
+				SetDefaultsVersion(instance, tt.version)
+			}
+			owned := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "edge", Namespace: "tenant"}}
+			recorded, err := RecordDefaultsVersion(context.Background(), cli, instance, tt.inventory, owned, latest)
+			if err != nil {
+				t.Fatalf("RecordDefaultsVersion() error = %v", err)
+			}
+			if recorded != tt.wantRecorded {
+				t.Errorf("recorded = %v, want %v", recorded, tt.wantRecorded)
+			}
+			if got := DefaultsVersion(instance); got != tt.wantVersion {
+				t.Errorf("DefaultsVersion() = %d, want %d", got, tt.wantVersion)
+			}
+		})
+	}
+}
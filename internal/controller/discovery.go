@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+)
+
+// ServerPreferredVersion returns the first of versions that the API server serves for group.
+// The versions should be ordered from the newest to the oldest.
+func ServerPreferredVersion(cfg *rest.Config, group string, versions ...string) (string, error) {
+	dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	groups, err := dc.ServerGroups()
+	if err != nil {
+		return "", err
+	}
+
+	served := make(map[string]struct{})
+	for _, g := range groups.Groups {
+		if g.Name != group {
+			continue
+		}
+		for _, v := range g.Versions {
+			served[v.Version] = struct{}{}
+		}
+	}
+
+	for _, v := range versions {
+		if _, ok := served[v]; ok {
+			return v, nil
+		}
+	}
+
+	return "", fmt.Errorf("the server doesn't serve any of the versions %v of group %q", versions, group)
+}
@@ -0,0 +1,17 @@
+package controller
+
+// Reasons of the events recorded on HigressGateway, HigressController and HigressConsole. The failed steps of a
+// reconcile are recorded with the reasons of the ReconcileError condition, e.g. DeploymentFailed.
+const (
+	ReasonCreated        = "Created"
+	ReasonUpdated        = "Updated"
+	ReasonDeleted        = "Deleted"
+	ReasonApplyFailed    = "ApplyFailed"
+	ReasonDriftDetected  = "DriftDetected"
+	ReasonFinalized      = "Finalized"
+	ReasonFinalizeFailed = "FinalizeFailed"
+	ReasonCRDInstalled   = "CRDInstalled"
+	ReasonCRDUpdated     = "CRDUpdated"
+	ReasonCRDNotOwned    = "CRDNotOwned"
+	ReasonCRDsSkipped    = "CRDsSkipped"
+)
@@ -0,0 +1,243 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// conflictingFields returns the paths of the fields of the apply conflict err, in the form of the
+// api server, e.g. .spec.template.spec.containers[name="gateway"].image.
+func conflictingFields(err error) []string {
+	status, ok := err.(errors.APIStatus)
+	if !ok || status.Status().Details == nil {
+		return nil
+	}
+
+	var fields []string
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type == metav1.CauseTypeFieldManagerConflict && cause.Field != "" {
+			fields = append(fields, cause.Field)
+		}
+	}
+	return fields
+}
+
+// pathElement is a step of a field path: the name of a field, the index of a list item, the
+// fields identifying an item of an associative list or the value of an item of a set.
+type pathElement struct {
+	field string
+	index *int
+	keys  map[string]interface{}
+	// isValue is set for the items of a set, which are identified by value.
+	isValue bool
+	value   interface{}
+}
+
+// parseFieldPath parses a field path in the form of the api server.
+func parseFieldPath(path string) ([]pathElement, error) {
+	var elements []pathElement
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '.':
+			end := i + 1
+			for end < len(path) && path[end] != '.' && path[end] != '[' {
+				end++
+			}
+			elements = append(elements, pathElement{field: path[i+1 : end]})
+			i = end
+		case '[':
+			end, err := closingBracket(path, i)
+			if err != nil {
+				return nil, err
+			}
+			element, err := parseSelector(path[i+1 : end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid field path %q: %v", path, err)
+			}
+			elements = append(elements, element)
+			i = end + 1
+		default:
+			return nil, fmt.Errorf("invalid field path %q at %d", path, i)
+		}
+	}
+	return elements, nil
+}
+
+// closingBracket returns the index of the bracket closing the one at start, skipping the
+// brackets inside the quoted values.
+func closingBracket(path string, start int) (int, error) {
+	quoted := false
+	for i := start + 1; i < len(path); i++ {
+		switch {
+		case quoted && path[i] == '\\':
+			i++
+		case path[i] == '"':
+			quoted = !quoted
+		case !quoted && path[i] == ']':
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid field path %q: unclosed bracket at %d", path, start)
+}
+
+// parseSelector parses the selector of a list item: an index, =value or key=value pairs.
+func parseSelector(selector string) (pathElement, error) {
+	if index, err := strconv.Atoi(selector); err == nil {
+		return pathElement{index: &index}, nil
+	}
+	if strings.HasPrefix(selector, "=") {
+		var value interface{}
+		if err := json.Unmarshal([]byte(selector[1:]), &value); err != nil {
+			return pathElement{}, err
+		}
+		return pathElement{isValue: true, value: value}, nil
+	}
+
+	// the values are JSON, the pairs are parsed as the members of an object
+	var keys map[string]interface{}
+	object := "{" + quoteKeys(selector) + "}"
+	if err := json.Unmarshal([]byte(object), &keys); err != nil {
+		return pathElement{}, err
+	}
+	return pathElement{keys: keys}, nil
+}
+
+// quoteKeys turns the pairs k1=v1,k2=v2 into "k1":v1,"k2":v2.
+func quoteKeys(selector string) string {
+	var b strings.Builder
+	quoted, atKey := false, true
+	for i := 0; i < len(selector); i++ {
+		c := selector[i]
+		switch {
+		case quoted:
+			b.WriteByte(c)
+			if c == '\\' && i+1 < len(selector) {
+				i++
+				b.WriteByte(selector[i])
+			} else if c == '"' {
+				quoted = false
+			}
+		case atKey:
+			b.WriteByte('"')
+			for i < len(selector) && selector[i] != '=' {
+				b.WriteByte(selector[i])
+				i++
+			}
+			b.WriteString(`":`)
+			atKey = false
+		case c == '"':
+			quoted = true
+			b.WriteByte(c)
+		case c == ',':
+			atKey = true
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// removeField removes the field at path from content. Paths that don't exist in content are
+// ignored.
+func removeField(content map[string]interface{}, path string) error {
+	elements, err := parseFieldPath(path)
+	if err != nil {
+		return err
+	}
+	if len(elements) == 0 {
+		return nil
+	}
+
+	removeElement(content, elements)
+	return nil
+}
+
+// removeElement removes the last of elements from parent, which is a map or a list, and returns
+// the updated parent.
+func removeElement(parent interface{}, elements []pathElement) interface{} {
+	element := elements[0]
+	switch parent := parent.(type) {
+	case map[string]interface{}:
+		if element.field == "" {
+			return parent
+		}
+		// the keys of the maps may contain dots, e.g. the labels, which the path doesn't escape, so
+		// the longest key made of the following field names is looked up first
+		fields := 1
+		for fields < len(elements) && elements[fields].field != "" {
+			fields++
+		}
+		name, rest := element.field, elements[1:]
+		for n := fields; n > 0; n-- {
+			names := make([]string, 0, n)
+			for _, e := range elements[:n] {
+				names = append(names, e.field)
+			}
+			if _, ok := parent[strings.Join(names, ".")]; ok {
+				name, rest = strings.Join(names, "."), elements[n:]
+				break
+			}
+		}
+		if len(rest) == 0 {
+			delete(parent, name)
+		} else if child, ok := parent[name]; ok {
+			parent[name] = removeElement(child, rest)
+		}
+		return parent
+	case []interface{}:
+		for i, item := range parent {
+			if !element.matches(i, item) {
+				continue
+			}
+			if len(elements) == 1 {
+				return append(parent[:i:i], parent[i+1:]...)
+			}
+			parent[i] = removeElement(item, elements[1:])
+			return parent
+		}
+	}
+	return parent
+}
+
+// matches returns true if the list item at index is the one selected by e.
+func (e pathElement) matches(index int, item interface{}) bool {
+	switch {
+	case e.index != nil:
+		return *e.index == index
+	case e.keys != nil:
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range e.keys {
+			if !jsonEqual(fields[k], v) {
+				return false
+			}
+		}
+		return true
+	case e.isValue:
+		return jsonEqual(item, e.value)
+	}
+	return false
+}
+
+// jsonEqual compares the values of an unstructured object and of a JSON document, whose numbers
+// are decoded as int64 and float64 respectively.
+func jsonEqual(a, b interface{}) bool {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	var decoded interface{}
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(decoded, b)
+}
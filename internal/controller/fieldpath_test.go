@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func newDeploymentContent() map[string]interface{} {
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{
+				"app":                    "higress-gateway",
+				"app.kubernetes.io/name": "higress",
+			},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name":  "higress-gateway",
+							"image": "higress/gateway:1.3.0",
+							"ports": []interface{}{
+								map[string]interface{}{"containerPort": int64(80), "protocol": "TCP"},
+								map[string]interface{}{"containerPort": int64(443), "protocol": "TCP"},
+							},
+						},
+					},
+					"finalizers": []interface{}{"a", "b"},
+				},
+			},
+		},
+	}
+}
+
+func TestRemoveField(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		modify func(content map[string]interface{})
+	}{
+		{
+			name: "field",
+			path: ".spec.replicas",
+			modify: func(content map[string]interface{}) {
+				delete(content["spec"].(map[string]interface{}), "replicas")
+			},
+		},
+		{
+			name: "map key with dots",
+			path: ".metadata.labels.app.kubernetes.io/name",
+			modify: func(content map[string]interface{}) {
+				delete(content["metadata"].(map[string]interface{})["labels"].(map[string]interface{}), "app.kubernetes.io/name")
+			},
+		},
+		{
+			name: "field of a list item",
+			path: `.spec.template.spec.containers[name="higress-gateway"].image`,
+			modify: func(content map[string]interface{}) {
+				container := podSpec(content)["containers"].([]interface{})[0].(map[string]interface{})
+				delete(container, "image")
+			},
+		},
+		{
+			name: "list item with several keys",
+			path: `.spec.template.spec.containers[name="higress-gateway"].ports[containerPort=443,protocol="TCP"]`,
+			modify: func(content map[string]interface{}) {
+				container := podSpec(content)["containers"].([]interface{})[0].(map[string]interface{})
+				container["ports"] = container["ports"].([]interface{})[:1]
+			},
+		},
+		{
+			name: "set item",
+			path: `.spec.template.spec.finalizers[="a"]`,
+			modify: func(content map[string]interface{}) {
+				podSpec(content)["finalizers"] = []interface{}{"b"}
+			},
+		},
+		{
+			name:   "missing field",
+			path:   `.spec.template.spec.containers[name="other"].image`,
+			modify: func(map[string]interface{}) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, want := newDeploymentContent(), newDeploymentContent()
+			tt.modify(want)
+			if err := removeField(content, tt.path); err != nil {
+				t.Fatalf("removeField() error = %v", err)
+			}
+			if !reflect.DeepEqual(content, want) {
+				t.Errorf("removeField() = %v, want %v", content, want)
+			}
+		})
+	}
+}
+
+func TestRemoveFieldInvalidPath(t *testing.T) {
+	for _, path := range []string{"spec", `.spec.containers[name="gateway"`, ".spec[name=gateway]"} {
+		if err := removeField(newDeploymentContent(), path); err == nil {
+			t.Errorf("removeField(%q) error = nil, want an error", path)
+		}
+	}
+}
+
+func TestConflictingFields(t *testing.T) {
+	err := errors.NewApplyConflict([]metav1.StatusCause{
+		{Type: metav1.CauseTypeFieldManagerConflict, Field: ".spec.replicas", Message: `conflict with "kube-controller-manager"`},
+		{Type: metav1.CauseTypeFieldValueInvalid, Field: ".spec.selector"},
+	}, "Apply failed with 1 conflict")
+
+	if got, want := conflictingFields(err), []string{".spec.replicas"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("conflictingFields() = %v, want %v", got, want)
+	}
+	if got := conflictingFields(errors.NewNotFound(schema.GroupResource{Resource: "deployments"}, "gateway")); got != nil {
+		t.Errorf("conflictingFields() = %v, want nil", got)
+	}
+}
+
+func podSpec(content map[string]interface{}) map[string]interface{} {
+	return content["spec"].(map[string]interface{})["template"].(map[string]interface{})["spec"].(map[string]interface{})
+}
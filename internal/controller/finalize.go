@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+// DeleteClusterRBAC removes the service accounts in namespace from the ClusterRoleBinding
+// bindingName, deletes the binding once it has no subjects left and then deletes the ClusterRole
+// clusterRoleName if nothing is bound to it anymore. Missing objects are ignored.
+func DeleteClusterRBAC(ctx context.Context, cli client.Client, bindingName, clusterRoleName, namespace string, logger logr.Logger) error {
+	crb := &rbacv1.ClusterRoleBinding{}
+	if err := cli.Get(ctx, types.NamespacedName{Name: bindingName}, crb); client.IgnoreNotFound(err) != nil {
+		return err
+	} else if err == nil {
+		if crb.Subjects = RemoveSubjects(crb, namespace); len(crb.Subjects) > 0 {
+			if err = cli.Update(ctx, crb); err != nil {
+				return err
+			}
+			logger.Info(fmt.Sprintf("remove the subjects of namespace %s from ClusterRoleBinding %s", namespace, bindingName))
+		} else {
+			if err = cli.Delete(ctx, crb); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+			logger.Info(fmt.Sprintf("delete ClusterRoleBinding %s", bindingName))
+		}
+	}
+
+	if bound, err := IsClusterRoleBound(ctx, cli, clusterRoleName); err != nil || bound {
+		return err
+	}
+
+	cr := &rbacv1.ClusterRole{}
+	cr.Name = clusterRoleName
+	if err := cli.Delete(ctx, cr); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	logger.Info(fmt.Sprintf("delete ClusterRole %s", clusterRoleName))
+	return nil
+}
+
+// Orphan removes the owner references to owner from the namespaced objects of inventory, so that
+// the garbage collector keeps them after owner is deleted.
+func Orphan(ctx context.Context, cli client.Client, owner client.Object, inventory []v1beta1.ResourceRef, logger logr.Logger) error {
+	for _, ref := range inventory {
+		if ref.Namespace == "" {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind))
+		if err := cli.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, obj); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				return err
+			}
+			continue
+		}
+
+		refs := obj.GetOwnerReferences()
+		kept := refs[:0]
+		for _, ownerRef := range refs {
+			if ownerRef.UID != owner.GetUID() {
+				kept = append(kept, ownerRef)
+			}
+		}
+		if len(kept) == len(obj.GetOwnerReferences()) {
+			continue
+		}
+
+		obj.SetOwnerReferences(kept)
+		if err := cli.Update(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		logger.Info(fmt.Sprintf("retain object {%s:%s/%s}", ref.Kind, ref.Namespace, ref.Name))
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	apiv1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+func TestOrphan(t *testing.T) {
+	owner := newOwner()
+	controlled := true
+	ownerRef := metav1.OwnerReference{APIVersion: v1beta1.GroupVersion.String(), Kind: "HigressGateway",
+		Name: owner.Name, UID: owner.UID, Controller: &controlled}
+	otherRef := metav1.OwnerReference{APIVersion: "v1", Kind: "ConfigMap", Name: "other", UID: "other-uid"}
+
+	owned := newConfigMap("owned", managedLabels("edge"))
+	owned.OwnerReferences = []metav1.OwnerReference{otherRef, ownerRef}
+	unowned := newConfigMap("unowned", nil)
+	unowned.OwnerReferences = []metav1.OwnerReference{otherRef}
+	cli := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(owned, unowned).Build()
+
+	inventory := []v1beta1.ResourceRef{
+		configMapRef("owned"),
+		configMapRef("unowned"),
+		configMapRef("missing"),
+		clusterRef("ClusterRole", "shared"),
+	}
+	if err := Orphan(context.Background(), cli, owner, inventory, logr.Discard()); err != nil {
+		t.Fatalf("Orphan() error = %v", err)
+	}
+
+	for _, name := range []string{"owned", "unowned"} {
+		cm := &apiv1.ConfigMap{}
+		if err := cli.Get(context.Background(), types.NamespacedName{Namespace: "tenant", Name: name}, cm); err != nil {
+			t.Fatalf("ConfigMap %s: error = %v", name, err)
+		}
+		if len(cm.OwnerReferences) != 1 || cm.OwnerReferences[0].UID != otherRef.UID {
+			t.Errorf("ConfigMap %s: ownerReferences = %v, want only %v", name, cm.OwnerReferences, otherRef)
+		}
+	}
+}
+
+func TestDeleteClusterRBAC(t *testing.T) {
+	binding := func(name string, namespaces ...string) *rbacv1.ClusterRoleBinding {
+		crb := &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: name},
+		}
+		for _, ns := range namespaces {
+			crb.Subjects = append(crb.Subjects, rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Namespace: ns, Name: "higress"})
+		}
+		return crb
+	}
+	cli := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(
+		binding("shared", "tenant", "other"), &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "shared"}},
+		binding("own", "tenant"), &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "own"}},
+	).Build()
+	ctx := context.Background()
+
+	for _, name := range []string{"shared", "own", "missing"} {
+		if err := DeleteClusterRBAC(ctx, cli, name, name, "tenant", logr.Discard()); err != nil {
+			t.Fatalf("DeleteClusterRBAC(%s) error = %v", name, err)
+		}
+	}
+	// deleting again is a no-op
+	if err := DeleteClusterRBAC(ctx, cli, "own", "own", "tenant", logr.Discard()); err != nil {
+		t.Fatalf("DeleteClusterRBAC(own) again error = %v", err)
+	}
+
+	crb := &rbacv1.ClusterRoleBinding{}
+	if err := cli.Get(ctx, types.NamespacedName{Name: "shared"}, crb); err != nil {
+		t.Fatalf("ClusterRoleBinding shared: error = %v", err)
+	}
+	if len(crb.Subjects) != 1 || crb.Subjects[0].Namespace != "other" {
+		t.Errorf("subjects = %v, want only the ones of namespace other", crb.Subjects)
+	}
+	if err := cli.Get(ctx, types.NamespacedName{Name: "shared"}, &rbacv1.ClusterRole{}); err != nil {
+		t.Errorf("ClusterRole shared: error = %v, want it kept while bound", err)
+	}
+	if err := cli.Get(ctx, types.NamespacedName{Name: "own"}, &rbacv1.ClusterRoleBinding{}); !errors.IsNotFound(err) {
+		t.Errorf("ClusterRoleBinding own: error = %v, want it deleted", err)
+	}
+	if err := cli.Get(ctx, types.NamespacedName{Name: "own"}, &rbacv1.ClusterRole{}); !errors.IsNotFound(err) {
+		t.Errorf("ClusterRole own: error = %v, want it deleted", err)
+	}
+}
@@ -0,0 +1,190 @@
+package higress
+
+import (
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
+	. "github.com/alibaba/higress/higress-operator/internal/controller"
+	"github.com/alibaba/higress/higress-operator/internal/controller/higressconsole"
+	"github.com/alibaba/higress/higress-operator/internal/controller/higresscontroller"
+	"github.com/alibaba/higress/higress-operator/internal/controller/higressgateway"
+)
+
+// accessLogFormat is the access log format of the helm chart.
+const accessLogFormat = `{"authority":"%REQ(:AUTHORITY)%","bytes_received":"%BYTES_RECEIVED%","bytes_sent":"%BYTES_SENT%",` +
+	`"downstream_local_address":"%DOWNSTREAM_LOCAL_ADDRESS%","downstream_remote_address":"%DOWNSTREAM_REMOTE_ADDRESS%",` +
+	`"duration":"%DURATION%","istio_policy_status":"%DYNAMIC_METADATA(istio.mixer:status)%","method":"%REQ(:METHOD)%",` +
+	`"path":"%REQ(X-ENVOY-ORIGINAL-PATH?:PATH)%","protocol":"%PROTOCOL%","request_id":"%REQ(X-REQUEST-ID)%",` +
+	`"requested_server_name":"%REQUESTED_SERVER_NAME%","response_code":"%RESPONSE_CODE%","response_flags":"%RESPONSE_FLAGS%",` +
+	`"route_name":"%ROUTE_NAME%","start_time":"%START_TIME%","trace_id":"%REQ(X-B3-TRACEID)%",` +
+	`"upstream_cluster":"%UPSTREAM_CLUSTER%","upstream_host":"%UPSTREAM_HOST%","upstream_local_address":"%UPSTREAM_LOCAL_ADDRESS%",` +
+	`"upstream_service_time":"%RESP(X-ENVOY-UPSTREAM-SERVICE-TIME)%","upstream_transport_failure_reason":"%UPSTREAM_TRANSPORT_FAILURE_REASON%",` +
+	`"user_agent":"%REQ(USER-AGENT)%","x_forwarded_for":"%REQ(X-FORWARDED-FOR)%"}`
+
+// initController renders the HigressController of the stack, live is the existing one or nil.
+func initController(ctrl *operatorv1beta1.HigressController, instance *operatorv1beta1.Higress, live client.Object) *operatorv1beta1.HigressController {
+	component := instance.Spec.Controller
+	*ctrl = operatorv1beta1.HigressController{
+		ObjectMeta: componentMeta(instance, getControllerName(instance)),
+		Spec: operatorv1beta1.HigressControllerSpec{
+			CRDCommonFields: commonFields(instance, component),
+			Controller: operatorv1beta1.ControllerSpec{
+				ContainerCommonFields: containerFields(instance, "controller", "higress", component.HigressContainer),
+				GatewayName:           getGatewayName(instance),
+				IngressClass:          instance.Spec.IngressClass,
+				WatchNamespace:        instance.Spec.WatchNamespace,
+				SDSTokenAud:           "istio-ca",
+			},
+			Pilot: operatorv1beta1.PilotSpec{
+				ContainerCommonFields:             containerFields(instance, "pilot", "pilot", instance.Spec.Pilot),
+				TraceSampling:                     "1.0",
+				KeepaliveMaxServerConnectionAge:   "30m",
+				ClusterDomain:                     "cluster.local",
+				EnableProtocolSniffingForOutbound: true,
+				EnableProtocolSniffingForInbound:  true,
+			},
+			CRDInstallPolicy: operatorv1beta1.CRDInstallPolicyInstall,
+			GatewayAPI:       instance.Spec.GatewayAPI,
+		},
+	}
+
+	// the settings of the pilot of the helm chart go first, so that they can be overridden
+	ctrl.Spec.Pilot.Env = append([]apiv1.EnvVar{
+		{Name: "PILOT_SCOPE_GATEWAY_TO_NAMESPACE", Value: "false"},
+		{Name: "PILOT_ENABLE_METADATA_EXCHANGE", Value: "false"},
+		{Name: "PILOT_ENABLE_CROSS_CLUSTER_WORKLOAD_ENTRY", Value: "false"},
+		{Name: "VALIDATION_ENABLED", Value: "false"},
+	}, ctrl.Spec.Pilot.Env...)
+
+	keepDefaultsVersion(ctrl, live, higresscontroller.LatestDefaultsVersion)
+	return ctrl
+}
+
+// initGateway renders the HigressGateway of the stack, live is the existing one or nil.
+func initGateway(gateway *operatorv1beta1.HigressGateway, instance *operatorv1beta1.Higress, live client.Object) *operatorv1beta1.HigressGateway {
+	component := instance.Spec.Gateway
+	*gateway = operatorv1beta1.HigressGateway{
+		ObjectMeta: componentMeta(instance, getGatewayName(instance)),
+		Spec: operatorv1beta1.HigressGatewaySpec{
+			CRDCommonFields:       commonFields(instance, component.HigressComponent),
+			ContainerCommonFields: containerFields(instance, getGatewayName(instance), "gateway", component.HigressContainer),
+			HostNetwork:           component.HostNetwork,
+			HighAvailability:      component.HighAvailability,
+			RollingMaxSurge:       intstr.FromString("100%"),
+			RollingMaxUnavailable: intstr.FromString("25%"),
+			MeshConfig: operatorv1beta1.MeshConfig{
+				TrustDomain:              "cluster.local",
+				AccessLogEncoding:        "TEXT",
+				AccessLogFile:            "/dev/stdout",
+				IngressControllerMode:    "OFF",
+				AccessLogFormat:          accessLogFormat,
+				DnsRefreshRate:           "200s",
+				EnablePrometheusMerge:    true,
+				ProtocolDetectionTimeout: "100ms",
+				ConfigSources:            []operatorv1beta1.ConfigSource{{Address: "xds://127.0.0.1:15051"}},
+				DefaultConfig: operatorv1beta1.ProxyConfig{
+					DisableAlpnH2: true,
+					ProxyStatsMatcher: &operatorv1beta1.ProxyStatsMatcher{
+						InclusionRegexps: []string{".*"},
+					},
+				},
+			},
+			ControllerRef: &operatorv1beta1.ControllerReference{Name: getControllerName(instance)},
+		},
+	}
+	gateway.Spec.Service = component.Service
+	gateway.Spec.AutoScaling = component.AutoScaling
+	gateway.Spec.Ports = component.Ports
+
+	keepDefaultsVersion(gateway, live, higressgateway.LatestDefaultsVersion)
+	return gateway
+}
+
+// initConsole renders the HigressConsole of the stack, live is the existing one or nil.
+func initConsole(console *operatorv1beta1.HigressConsole, instance *operatorv1beta1.Higress, live client.Object) *operatorv1beta1.HigressConsole {
+	component := instance.Spec.Console
+	*console = operatorv1beta1.HigressConsole{
+		ObjectMeta: componentMeta(instance, getConsoleName(instance)),
+		Spec: operatorv1beta1.HigressConsoleSpec{
+			CRDCommonFields:       commonFields(instance, component.HigressComponent),
+			ContainerCommonFields: containerFields(instance, "higress-console", "console", component.HigressContainer),
+			ControllerRef:         &operatorv1beta1.ControllerReference{Name: getControllerName(instance)},
+			AdminUsername:         component.AdminUsername,
+			Config:                component.Config,
+		},
+	}
+
+	keepDefaultsVersion(console, live, higressconsole.LatestDefaultsVersion)
+	return console
+}
+
+// componentMeta returns the metadata of the component name, which has the labels of the stack.
+func componentMeta(instance *operatorv1beta1.Higress, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: instance.Namespace,
+		Labels:    ObjectLabels(instance),
+	}
+}
+
+// commonFields propagates the settings shared by the components.
+func commonFields(instance *operatorv1beta1.Higress, component operatorv1beta1.HigressComponent) operatorv1beta1.CRDCommonFields {
+	return operatorv1beta1.CRDCommonFields{
+		Replicas:                      component.Replicas,
+		NodeSelector:                  component.NodeSelector,
+		Affinity:                      component.Affinity,
+		Toleration:                    component.Toleration,
+		TopologySpreadConstraints:     component.TopologySpreadConstraints,
+		PriorityClassName:             component.PriorityClassName,
+		SchedulerName:                 component.SchedulerName,
+		RuntimeClassName:              component.RuntimeClassName,
+		TerminationGracePeriodSeconds: component.TerminationGracePeriodSeconds,
+		PodDisruptionBudget:           component.PodDisruptionBudget,
+		EnableStatus:                  true,
+		JwtPolicy:                     instance.Spec.JwtPolicy,
+		Revision:                      instance.Spec.Revision,
+		DriftPolicy:                   instance.Spec.DriftPolicy,
+		DeletionPolicy:                instance.Spec.DeletionPolicy,
+	}
+}
+
+// containerFields returns the container of image hub/repository, whose tag is the tag of the
+// stack unless the component overrides it.
+func containerFields(instance *operatorv1beta1.Higress, name, repository string, container operatorv1beta1.HigressContainer) operatorv1beta1.ContainerCommonFields {
+	tag := instance.Spec.Tag
+	if container.Tag != "" {
+		tag = container.Tag
+	}
+
+	return operatorv1beta1.ContainerCommonFields{
+		Name: name,
+		Image: operatorv1beta1.Image{
+			Repository:      fmt.Sprintf("%s/%s", instance.Spec.Hub, repository),
+			Tag:             tag,
+			ImagePullPolicy: instance.Spec.ImagePullPolicy,
+		},
+		ImagePullSecrets: instance.Spec.ImagePullSecrets,
+		Env:              container.Env,
+		Resources:        container.Resources,
+	}
+}
+
+// keepDefaultsVersion records the defaults version of the live component on component, or latest
+// for a new component. The applies would otherwise drop the annotation recorded by the defaulting
+// webhook, which then records the initial version for the existing component.
+func keepDefaultsVersion(component, live client.Object, latest int) {
+	version := latest
+	if live != nil {
+		if _, ok := live.GetAnnotations()[AnnotationDefaultsVersion]; ok {
+			version = DefaultsVersion(live)
+		} else {
+			version = InitialDefaultsVersion
+		}
+	}
+	SetDefaultsVersion(component, version)
+}
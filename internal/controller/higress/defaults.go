@@ -0,0 +1,57 @@
+package higress
+
+import (
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
+	. "github.com/alibaba/higress/higress-operator/internal/controller"
+)
+
+const (
+	defaultHub          = "higress-registry.cn-hangzhou.cr.aliyuncs.com/higress"
+	defaultIngressClass = "higress"
+	defaultJwtPolicy    = "third-party-jwt"
+)
+
+// defaults lists the defaults of every defaults version, the defaults of version n are applied
+// before the ones of the older versions, so that they take precedence. A change of the defaults has
+// to be added as a new version.
+var defaults = []func(instance *operatorv1beta1.Higress){
+	defaultsV1,
+}
+
+// LatestDefaultsVersion is the version of the defaults of the new instances.
+var LatestDefaultsVersion = len(defaults)
+
+// SetDefaults fills the unset fields of instance with the defaults of its defaults version.
+func SetDefaults(instance *operatorv1beta1.Higress) {
+	version := DefaultsVersion(instance)
+	if version > len(defaults) {
+		version = len(defaults)
+	}
+	for i := version - 1; i >= 0; i-- {
+		defaults[i](instance)
+	}
+}
+
+func defaultsV1(instance *operatorv1beta1.Higress) {
+	if instance.Spec.Hub == "" {
+		instance.Spec.Hub = defaultHub
+	}
+	if instance.Spec.IngressClass == "" {
+		instance.Spec.IngressClass = defaultIngressClass
+	}
+	if instance.Spec.JwtPolicy == "" {
+		instance.Spec.JwtPolicy = defaultJwtPolicy
+	}
+	// driftPolicy
+	if instance.Spec.DriftPolicy == "" {
+		instance.Spec.DriftPolicy = operatorv1beta1.DriftPolicyEnforce
+	}
+	// deletionPolicy
+	if instance.Spec.DeletionPolicy == "" {
+		instance.Spec.DeletionPolicy = operatorv1beta1.DeletionPolicyDelete
+	}
+	// gatewayAPI
+	if instance.Spec.GatewayAPI != nil && instance.Spec.GatewayAPI.Channel == "" {
+		instance.Spec.GatewayAPI.Channel = operatorv1beta1.GatewayAPIChannelStandard
+	}
+}
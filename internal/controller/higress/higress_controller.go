@@ -0,0 +1,306 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package higress
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
+	. "github.com/alibaba/higress/higress-operator/internal/controller"
+)
+
+const (
+	finalizer = "higress.higress.io/finalizer"
+)
+
+// reconcileStep creates or updates one of the components of a Higress stack.
+type reconcileStep struct {
+	name          string
+	failureReason string
+	run           func(context.Context, *operatorv1beta1.Higress, logr.Logger) error
+}
+
+// HigressReconciler reconciles a Higress object
+type HigressReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=operator.higress.io,resources=higresses,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=operator.higress.io,resources=higresses/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=operator.higress.io,resources=higresses/finalizers,verbs=update
+//+kubebuilder:rbac:groups=operator.higress.io,resources=higresscontrollers;higressgateways;higressconsoles,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile creates the components of a Higress stack with the shared settings of the stack, and
+// aggregates their readiness.
+func (r *HigressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	instance := &operatorv1beta1.Higress{}
+	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info(fmt.Sprintf("Higress(%v) resource not found", req.NamespacedName))
+			return ctrl.Result{}, nil
+		}
+
+		logger.Error(err, "Failed to get resource Higress(%v)", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
+
+	// instances that were not admitted by the webhook
+	if recorded, err := RecordDefaultsVersion(ctx, r.Client, instance, instance.Status.Inventory,
+		&operatorv1beta1.HigressController{ObjectMeta: metav1.ObjectMeta{Namespace: instance.Namespace, Name: getControllerName(instance)}}, LatestDefaultsVersion); err != nil {
+		logger.Error(err, "Failed to record the defaults version")
+		return ctrl.Result{}, err
+	} else if recorded {
+		if err = r.Update(ctx, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// instances that were not defaulted by the webhook
+	SetDefaults(instance)
+
+	ctx = WithApplyOptions(ctx, ApplyOptions{
+		Owner:       instance,
+		Recorder:    r.Recorder,
+		DriftPolicy: instance.Spec.DriftPolicy,
+		// drifts can only be told apart from changes of the spec once the spec has been applied
+		DetectDrift: instance.Status.ObservedGeneration == instance.Generation,
+	})
+
+	// if deletionTimeStamp is not nil, it means is marked to be deleted
+	if instance.GetDeletionTimestamp() != nil {
+		if controllerutil.ContainsFinalizer(instance, finalizer) {
+			if err := r.finalizeHigress(ctx, instance, logger); err != nil {
+				r.Recorder.Event(instance, apiv1.EventTypeWarning, ReasonFinalizeFailed, err.Error())
+				return ctrl.Result{}, err
+			}
+			r.Recorder.Eventf(instance, apiv1.EventTypeNormal, ReasonFinalized, "Finalized the instance with deletion policy %s", instance.Spec.DeletionPolicy)
+
+			controllerutil.RemoveFinalizer(instance, finalizer)
+
+			if err := r.Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	// check if the namespace still exists during the reconciling
+	ns, nn := &apiv1.Namespace{}, types.NamespacedName{Name: instance.Namespace, Namespace: apiv1.NamespaceAll}
+	err := r.Get(ctx, nn, ns)
+	if (err != nil && errors.IsNotFound(err)) || (ns.Status.Phase == apiv1.NamespaceTerminating) {
+		logger.Info(fmt.Sprintf("The namespace (%s) doesn't exist or is in Terminating status, canceling Reconciling", instance.Namespace))
+		return ctrl.Result{}, nil
+	} else if err != nil {
+		logger.Error(err, "Failed to check if namespace exists")
+		return ctrl.Result{}, nil
+	}
+
+	// add finalizer for this CR
+	if controllerutil.AddFinalizer(instance, finalizer) {
+		if err = r.Update(ctx, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	instance.Status.Components = nil
+	for _, step := range []reconcileStep{
+		{StepController, ReasonControllerFailed, r.createController},
+		{StepGateway, ReasonGatewayFailed, r.createGateway},
+		{StepConsole, ReasonConsoleFailed, r.createConsole},
+		{StepPrune, ReasonPruneFailed, r.prune},
+	} {
+		if err = ObserveStep("Higress", step.name, func() error {
+			return step.run(ctx, instance, logger)
+		}); err != nil {
+			return r.reconcileFailed(ctx, instance, step.failureReason, err, logger)
+		}
+	}
+
+	ClearReconcileError(&instance.Status.Conditions, instance.Generation)
+	SetComponentsReady(&instance.Status.Conditions, instance.Generation, instance.Status.Components)
+	instance.Status.ObservedGeneration = instance.Generation
+	if err = r.updateStatus(ctx, instance); err != nil {
+		logger.Error(err, "Failed to update higress/status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: DriftCheckInterval}, nil
+}
+
+// reconcileFailed records the failed step in the status and returns err to requeue the request.
+func (r *HigressReconciler) reconcileFailed(ctx context.Context, instance *operatorv1beta1.Higress,
+	reason string, err error, logger logr.Logger) (ctrl.Result, error) {
+	r.Recorder.Event(instance, apiv1.EventTypeWarning, reason, err.Error())
+	SetReconcileError(&instance.Status.Conditions, instance.Generation, reason, err)
+	if statusErr := r.updateStatus(ctx, instance); statusErr != nil {
+		logger.Error(statusErr, "Failed to update higress/status")
+	}
+	return ctrl.Result{}, err
+}
+
+func (r *HigressReconciler) updateStatus(ctx context.Context, instance *operatorv1beta1.Higress) error {
+	SetFieldConflicts(&instance.Status.Conditions, instance.Generation, FieldConflicts(ctx))
+	return r.Status().Update(ctx, instance)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *HigressReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&operatorv1beta1.Higress{}).
+		Owns(&operatorv1beta1.HigressController{}).
+		Owns(&operatorv1beta1.HigressGateway{}).
+		Owns(&operatorv1beta1.HigressConsole{}).
+		Complete(r)
+}
+
+func (r *HigressReconciler) createController(ctx context.Context, instance *operatorv1beta1.Higress, logger logr.Logger) error {
+	live, err := r.getLive(ctx, &operatorv1beta1.HigressController{}, instance.Namespace, getControllerName(instance))
+	if err != nil {
+		return err
+	}
+
+	controller := initController(&operatorv1beta1.HigressController{}, instance, live)
+	if err = ctrl.SetControllerReference(instance, controller, r.Scheme); err != nil {
+		return err
+	}
+	if err = ApplySetFields(ctx, r.Client, "HigressController", controller, logger); err != nil {
+		return err
+	}
+
+	instance.Status.Components = append(instance.Status.Components, componentStatus("HigressController",
+		controller, controller.Status.ObservedGeneration, controller.Status.Conditions))
+	return nil
+}
+
+func (r *HigressReconciler) createGateway(ctx context.Context, instance *operatorv1beta1.Higress, logger logr.Logger) error {
+	live, err := r.getLive(ctx, &operatorv1beta1.HigressGateway{}, instance.Namespace, getGatewayName(instance))
+	if err != nil {
+		return err
+	}
+
+	gateway := initGateway(&operatorv1beta1.HigressGateway{}, instance, live)
+	if err = ctrl.SetControllerReference(instance, gateway, r.Scheme); err != nil {
+		return err
+	}
+	if err = ApplySetFields(ctx, r.Client, "HigressGateway", gateway, logger); err != nil {
+		return err
+	}
+
+	instance.Status.Components = append(instance.Status.Components, componentStatus("HigressGateway",
+		gateway, gateway.Status.ObservedGeneration, gateway.Status.Conditions))
+	return nil
+}
+
+// createConsole applies the console of the stack, it is pruned once the console is disabled.
+func (r *HigressReconciler) createConsole(ctx context.Context, instance *operatorv1beta1.Higress, logger logr.Logger) error {
+	if instance.Spec.Console == nil {
+		return nil
+	}
+
+	live, err := r.getLive(ctx, &operatorv1beta1.HigressConsole{}, instance.Namespace, getConsoleName(instance))
+	if err != nil {
+		return err
+	}
+
+	console := initConsole(&operatorv1beta1.HigressConsole{}, instance, live)
+	if err = ctrl.SetControllerReference(instance, console, r.Scheme); err != nil {
+		return err
+	}
+	if err = ApplySetFields(ctx, r.Client, "HigressConsole", console, logger); err != nil {
+		return err
+	}
+
+	instance.Status.Components = append(instance.Status.Components, componentStatus("HigressConsole",
+		console, console.Status.ObservedGeneration, console.Status.Conditions))
+	return nil
+}
+
+// getLive returns the existing component obj, or nil if it doesn't exist yet.
+func (r *HigressReconciler) getLive(ctx context.Context, obj client.Object, namespace, name string) (client.Object, error) {
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, obj); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return obj, nil
+}
+
+// componentStatus returns the readiness of a component, which is unknown until the component has
+// reconciled its current spec.
+func componentStatus(kind string, obj client.Object, observedGeneration int64, conditions []metav1.Condition) operatorv1beta1.ComponentStatus {
+	status := operatorv1beta1.ComponentStatus{
+		Kind:    kind,
+		Name:    obj.GetName(),
+		Ready:   metav1.ConditionUnknown,
+		Message: "The current spec has not been reconciled yet",
+	}
+	if observedGeneration != obj.GetGeneration() {
+		return status
+	}
+
+	if failed := meta.FindStatusCondition(conditions, operatorv1beta1.ConditionReconcileError); failed != nil && failed.Status == metav1.ConditionTrue {
+		status.Ready = metav1.ConditionFalse
+		status.Message = failed.Message
+		return status
+	}
+	if ready := meta.FindStatusCondition(conditions, operatorv1beta1.ConditionReady); ready != nil {
+		status.Ready = ready.Status
+		status.Message = ready.Message
+	}
+	return status
+}
+
+// finalizeHigress deletes the components of the instance, which clean up their own objects, or
+// keeps them running without the instance if the deletion policy is Retain.
+func (r *HigressReconciler) finalizeHigress(ctx context.Context, instance *operatorv1beta1.Higress, logger logr.Logger) error {
+	if instance.Spec.DeletionPolicy == operatorv1beta1.DeletionPolicyRetain {
+		return Orphan(ctx, r.Client, instance, instance.Status.Inventory, logger)
+	}
+
+	return Prune(ctx, r.Client, instance.Status.Inventory, logger)
+}
+
+// prune deletes the components of the inventory that were not applied in this reconcile and
+// records the new inventory.
+func (r *HigressReconciler) prune(ctx context.Context, instance *operatorv1beta1.Higress, logger logr.Logger) error {
+	if err := Prune(ctx, r.Client, instance.Status.Inventory, logger); err != nil {
+		return err
+	}
+
+	instance.Status.Inventory = Inventory(ctx)
+	return nil
+}
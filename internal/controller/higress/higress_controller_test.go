@@ -0,0 +1,180 @@
+package higress
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
+	. "github.com/alibaba/higress/higress-operator/internal/controller"
+	"github.com/alibaba/higress/higress-operator/internal/controller/higressconsole"
+	"github.com/alibaba/higress/higress-operator/internal/controller/higresscontroller"
+	"github.com/alibaba/higress/higress-operator/internal/controller/higressgateway"
+)
+
+// applyAsUpdate stands in for the server-side applies, which the fake client doesn't merge. The
+// applied object replaces the live one, and a dry run returns the live one.
+func applyAsUpdate(ctx context.Context, cli client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return cli.Patch(ctx, obj, patch, opts...)
+	}
+
+	options := &client.PatchOptions{}
+	options.ApplyOptions(opts)
+	live := obj.DeepCopyObject().(client.Object)
+	if err := cli.Get(ctx, client.ObjectKeyFromObject(obj), live); err != nil {
+		if !errors.IsNotFound(err) || len(options.DryRun) > 0 {
+			return err
+		}
+		return cli.Create(ctx, obj)
+	}
+	if len(options.DryRun) > 0 {
+		return cli.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+	}
+
+	obj.SetResourceVersion(live.GetResourceVersion())
+	return cli.Update(ctx, obj)
+}
+
+func newReconciler(t *testing.T, objs ...client.Object) *HigressReconciler {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := operatorv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	objs = append(objs, &apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "higress-system"}})
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+		WithStatusSubresource(&operatorv1beta1.Higress{}, &operatorv1beta1.HigressController{},
+			&operatorv1beta1.HigressGateway{}, &operatorv1beta1.HigressConsole{}).
+		WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsUpdate}).Build()
+	return &HigressReconciler{Client: cli, Scheme: scheme, Recorder: record.NewFakeRecorder(100)}
+}
+
+func newHigress() *operatorv1beta1.Higress {
+	instance := &operatorv1beta1.Higress{ObjectMeta: metav1.ObjectMeta{Name: "higress", Namespace: "higress-system"}}
+	instance.Spec.Tag = "2.0.0"
+	instance.Spec.JwtPolicy = "first-party-jwt"
+	instance.Spec.Revision = "canary"
+	instance.Spec.Pilot.Tag = "2.0.1"
+	instance.Spec.Console = &operatorv1beta1.HigressConsoleComponent{}
+	return instance
+}
+
+func reconcile(t *testing.T, r *HigressReconciler) {
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "higress-system", Name: "higress"}}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+}
+
+func getComponent(t *testing.T, r *HigressReconciler, name string, obj client.Object) {
+	if err := r.Get(context.Background(), types.NamespacedName{Namespace: "higress-system", Name: name}, obj); err != nil {
+		t.Fatalf("Get(%s) error = %v", name, err)
+	}
+}
+
+func TestReconcileSharedSettings(t *testing.T) {
+	r := newReconciler(t, newHigress())
+	reconcile(t, r)
+
+	controller, gateway, console := &operatorv1beta1.HigressController{}, &operatorv1beta1.HigressGateway{}, &operatorv1beta1.HigressConsole{}
+	getComponent(t, r, "higress-controller", controller)
+	getComponent(t, r, "higress-gateway", gateway)
+	getComponent(t, r, "higress-console", console)
+
+	// the controller selects the gateway of the stack, whose pods get the label from the controllerRef
+	if got := controller.Spec.Controller.GatewayName; got != gateway.Name {
+		t.Errorf("gatewayName = %q, want %q", got, gateway.Name)
+	}
+	if ref := gateway.Spec.ControllerRef; ref == nil || ref.Name != controller.Name {
+		t.Errorf("gateway controllerRef = %v, want %s", ref, controller.Name)
+	}
+	if ref := console.Spec.ControllerRef; ref == nil || ref.Name != controller.Name {
+		t.Errorf("console controllerRef = %v, want %s", ref, controller.Name)
+	}
+	if got := higresscontroller.GatewaySelectorValue(controller); got != "higress-system-higress-gateway" {
+		t.Errorf("gateway selector = %q, want higress-system-higress-gateway", got)
+	}
+	defaulted := gateway.DeepCopy()
+	higressgateway.SetDefaults(defaulted)
+	if _, ok := defaulted.Spec.SelectorLabels[higresscontroller.GatewaySelectorKey]; ok || defaulted.Spec.SelectorLabels["app"] != gateway.Name {
+		t.Errorf("gateway selectorLabels = %v, want app=%s without the label of the controller", defaulted.Spec.SelectorLabels, gateway.Name)
+	}
+
+	for name, common := range map[string]operatorv1beta1.CRDCommonFields{
+		"controller": controller.Spec.CRDCommonFields,
+		"gateway":    gateway.Spec.CRDCommonFields,
+		"console":    console.Spec.CRDCommonFields,
+	} {
+		if common.JwtPolicy != "first-party-jwt" || common.Revision != "canary" {
+			t.Errorf("%s jwtPolicy = %q, revision = %q, want the ones of the stack", name, common.JwtPolicy, common.Revision)
+		}
+	}
+
+	// the tag of the stack unless the component overrides it
+	for name, image := range map[string]operatorv1beta1.Image{
+		"controller": controller.Spec.Controller.Image,
+		"gateway":    gateway.Spec.Image,
+		"console":    console.Spec.Image,
+	} {
+		if image.Tag != "2.0.0" {
+			t.Errorf("%s tag = %q, want 2.0.0", name, image.Tag)
+		}
+	}
+	if got := controller.Spec.Pilot.Image.Tag; got != "2.0.1" {
+		t.Errorf("pilot tag = %q, want 2.0.1", got)
+	}
+}
+
+func TestReconcilePrunesConsole(t *testing.T) {
+	r := newReconciler(t, newHigress())
+	reconcile(t, r)
+	getComponent(t, r, "higress-console", &operatorv1beta1.HigressConsole{})
+
+	instance := &operatorv1beta1.Higress{}
+	getComponent(t, r, "higress", instance)
+	instance.Spec.Console = nil
+	if err := r.Update(context.Background(), instance); err != nil {
+		t.Fatal(err)
+	}
+	reconcile(t, r)
+
+	err := r.Get(context.Background(), types.NamespacedName{Namespace: "higress-system", Name: "higress-console"}, &operatorv1beta1.HigressConsole{})
+	if !errors.IsNotFound(err) {
+		t.Errorf("Get(higress-console) error = %v, want the disabled console pruned", err)
+	}
+	getComponent(t, r, "higress-gateway", &operatorv1beta1.HigressGateway{})
+}
+
+func TestReconcileKeepsDefaultsVersion(t *testing.T) {
+	// a gateway of the initial defaults version keeps it, the applies would otherwise drop the annotation
+	live := &operatorv1beta1.HigressGateway{ObjectMeta: metav1.ObjectMeta{Name: "higress-gateway", Namespace: "higress-system"}}
+	SetDefaultsVersion(live, InitialDefaultsVersion)
+	r := newReconciler(t, newHigress(), live)
+	reconcile(t, r)
+	reconcile(t, r)
+
+	gateway, console := &operatorv1beta1.HigressGateway{}, &operatorv1beta1.HigressConsole{}
+	getComponent(t, r, "higress-gateway", gateway)
+	getComponent(t, r, "higress-console", console)
+	if got := DefaultsVersion(gateway); got != InitialDefaultsVersion {
+		t.Errorf("gateway defaults version = %d, want %d", got, InitialDefaultsVersion)
+	}
+	if got := DefaultsVersion(console); got != higressconsole.LatestDefaultsVersion {
+		t.Errorf("console defaults version = %d, want %d", got, higressconsole.LatestDefaultsVersion)
+	}
+}
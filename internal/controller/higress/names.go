@@ -0,0 +1,22 @@
+package higress
+
+import (
+	"fmt"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+// The components of a stack are named after the Higress CR, a CR named higress gets the names of
+// the helm chart.
+
+func getControllerName(instance *v1beta1.Higress) string {
+	return fmt.Sprintf("%s-controller", instance.Name)
+}
+
+func getGatewayName(instance *v1beta1.Higress) string {
+	return fmt.Sprintf("%s-gateway", instance.Name)
+}
+
+func getConsoleName(instance *v1beta1.Higress) string {
+	return fmt.Sprintf("%s-console", instance.Name)
+}
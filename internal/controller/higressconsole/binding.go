@@ -0,0 +1,91 @@
+package higressconsole
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
+	"github.com/alibaba/higress/higress-operator/internal/controller/higresscontroller"
+)
+
+const defaultIngressClass = "higress"
+
+// controllerBinding holds the settings that a console takes from the HigressController it
+// administers.
+type controllerBinding struct {
+	// Namespace is the namespace of the controller.
+	Namespace    string
+	IngressClass string
+	// WatchNamespace is the namespace watched by the controller, all namespaces if empty.
+	WatchNamespace string
+	JwtPolicy      string
+}
+
+type controllerBindingKey struct{}
+
+func withControllerBinding(ctx context.Context, binding *controllerBinding) context.Context {
+	return context.WithValue(ctx, controllerBindingKey{}, binding)
+}
+
+func getControllerBinding(ctx context.Context, instance *operatorv1beta1.HigressConsole) *controllerBinding {
+	if binding, ok := ctx.Value(controllerBindingKey{}).(*controllerBinding); ok {
+		return binding
+	}
+	return defaultControllerBinding(instance)
+}
+
+// defaultControllerBinding is the binding of the consoles of a controller that isn't managed by
+// the operator, e.g. one installed by the helm chart.
+func defaultControllerBinding(instance *operatorv1beta1.HigressConsole) *controllerBinding {
+	return &controllerBinding{
+		Namespace:    instance.Namespace,
+		IngressClass: defaultIngressClass,
+		JwtPolicy:    instance.Spec.JwtPolicy,
+	}
+}
+
+// resolveControllerBinding looks up the HigressController of the controllerRef of instance. A
+// console without a controllerRef administers the only HigressController of its namespace.
+func resolveControllerBinding(ctx context.Context, cli client.Client, instance *operatorv1beta1.HigressConsole) (*controllerBinding, error) {
+	ctrl := &operatorv1beta1.HigressController{}
+	if ref := instance.Spec.ControllerRef; ref != nil {
+		key := client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}
+		if key.Namespace == "" {
+			key.Namespace = instance.Namespace
+		}
+		if err := cli.Get(ctx, key, ctrl); err != nil {
+			if errors.IsNotFound(err) {
+				return nil, fmt.Errorf("HigressController %s of the controllerRef is not found", key)
+			}
+			return nil, err
+		}
+	} else {
+		controllers := &operatorv1beta1.HigressControllerList{}
+		if err := cli.List(ctx, controllers, client.InNamespace(instance.Namespace)); err != nil {
+			return nil, err
+		}
+		switch len(controllers.Items) {
+		case 0:
+			return defaultControllerBinding(instance), nil
+		case 1:
+			ctrl = &controllers.Items[0]
+		default:
+			return nil, fmt.Errorf("namespace %s has %d HigressControllers, the controllerRef has to choose one",
+				instance.Namespace, len(controllers.Items))
+		}
+	}
+
+	// the reconciles of the console don't depend on the defaulting webhook of the controller
+	ctrl = ctrl.DeepCopy()
+	higresscontroller.SetDefaults(ctrl)
+
+	return &controllerBinding{
+		Namespace:      ctrl.Namespace,
+		IngressClass:   ctrl.Spec.Controller.IngressClass,
+		WatchNamespace: ctrl.Spec.Controller.WatchNamespace,
+		JwtPolicy:      ctrl.Spec.JwtPolicy,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package higressconsole
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+func TestResolveControllerBinding(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := v1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	newController := func(name string) *v1beta1.HigressController {
+		ctrl := &v1beta1.HigressController{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "tenant"}}
+		ctrl.Spec.Controller.IngressClass = "team-a"
+		ctrl.Spec.Controller.WatchNamespace = "team-a"
+		ctrl.Spec.JwtPolicy = "first-party-jwt"
+		return ctrl
+	}
+
+	tests := []struct {
+		name        string
+		controllers []client.Object
+		want        controllerBinding
+		wantErr     bool
+	}{
+		{
+			// a controller that isn't managed by the operator, e.g. one installed by the helm chart
+			name: "no controller",
+			want: controllerBinding{Namespace: "tenant", IngressClass: defaultIngressClass, JwtPolicy: "third-party-jwt"},
+		},
+		{
+			name:        "one controller",
+			controllers: []client.Object{newController("higress-controller")},
+			want: controllerBinding{Namespace: "tenant", IngressClass: "team-a", WatchNamespace: "team-a",
+				JwtPolicy: "first-party-jwt"},
+		},
+		{
+			name:        "several controllers",
+			controllers: []client.Object{newController("higress-controller"), newController("team-b")},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.controllers...).Build()
+			instance := &v1beta1.HigressConsole{ObjectMeta: metav1.ObjectMeta{Name: "higress-console", Namespace: "tenant"}}
+			instance.Spec.JwtPolicy = "third-party-jwt"
+
+			binding, err := resolveControllerBinding(context.Background(), cli, instance)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveControllerBinding() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if *binding != tt.want {
+				t.Errorf("binding = %+v, want %+v", *binding, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package higressconsole
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+// initConfigMap renders the config ConfigMap of instance. The console also stores its own settings
+// in the ConfigMap, the keys that are not in the spec are left to it.
+func initConfigMap(cm *apiv1.ConfigMap, instance *v1beta1.HigressConsole) *apiv1.ConfigMap {
+	*cm = apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getConfigMapName(instance),
+			Namespace: instance.Namespace,
+			Labels:    instance.Labels,
+		},
+		Data: instance.Spec.Config,
+	}
+	return cm
+}
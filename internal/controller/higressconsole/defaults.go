@@ -0,0 +1,80 @@
+package higressconsole
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
+	. "github.com/alibaba/higress/higress-operator/internal/controller"
+)
+
+const (
+	defaultAdminUsername = "admin"
+	consolePort          = 8080
+)
+
+// defaults lists the defaults of every defaults version, the defaults of version n are applied
+// before the ones of the older versions, so that they take precedence. A change of the defaults has
+// to be added as a new version.
+var defaults = []func(instance *operatorv1beta1.HigressConsole){
+	defaultsV1,
+}
+
+// LatestDefaultsVersion is the version of the defaults of the new instances.
+var LatestDefaultsVersion = len(defaults)
+
+// SetDefaults fills the unset fields of instance with the defaults of its defaults version.
+func SetDefaults(instance *operatorv1beta1.HigressConsole) {
+	version := DefaultsVersion(instance)
+	if version > len(defaults) {
+		version = len(defaults)
+	}
+	for i := version - 1; i >= 0; i-- {
+		defaults[i](instance)
+	}
+}
+
+func defaultsV1(instance *operatorv1beta1.HigressConsole) {
+	if instance.Spec.RBAC == nil {
+		instance.Spec.RBAC = &operatorv1beta1.RBAC{Enable: true}
+	}
+	// serviceAccount
+	if instance.Spec.ServiceAccount == nil {
+		instance.Spec.ServiceAccount = &operatorv1beta1.ServiceAccount{Enable: true, Name: instance.Name}
+	}
+	// replicas
+	if instance.Spec.Replicas == nil {
+		replicas := int32(1)
+		instance.Spec.Replicas = &replicas
+	}
+	// driftPolicy
+	if instance.Spec.DriftPolicy == "" {
+		instance.Spec.DriftPolicy = operatorv1beta1.DriftPolicyEnforce
+	}
+	// deletionPolicy
+	if instance.Spec.DeletionPolicy == "" {
+		instance.Spec.DeletionPolicy = operatorv1beta1.DeletionPolicyDelete
+	}
+	// selectorLabels
+	if len(instance.Spec.SelectorLabels) == 0 {
+		instance.Spec.SelectorLabels = map[string]string{"app": instance.Name}
+	}
+	// adminUsername
+	if instance.Spec.AdminUsername == "" {
+		instance.Spec.AdminUsername = defaultAdminUsername
+	}
+	// service
+	if instance.Spec.Service == nil {
+		instance.Spec.Service = &operatorv1beta1.Service{
+			Type: string(apiv1.ServiceTypeClusterIP),
+			Ports: []apiv1.ServicePort{
+				{
+					Name:       "http",
+					Port:       consolePort,
+					Protocol:   "TCP",
+					TargetPort: intstr.FromInt(consolePort),
+				},
+			},
+		}
+	}
+}
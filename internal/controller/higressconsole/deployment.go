@@ -0,0 +1,163 @@
+package higressconsole
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+	"github.com/alibaba/higress/higress-operator/internal/controller"
+	"github.com/alibaba/higress/higress-operator/internal/controller/higresscontroller"
+)
+
+// controllerAPIPort is the port of the API of the controller, which the console reads the
+// status of the configs from.
+const controllerAPIPort = 8888
+
+func initDeployment(deploy *appsv1.Deployment, instance *v1beta1.HigressConsole, binding *controllerBinding) *appsv1.Deployment {
+	*deploy = appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        instance.Name,
+			Namespace:   instance.Namespace,
+			Labels:      instance.Labels,
+			Annotations: controller.ObjectAnnotations(instance),
+		},
+	}
+
+	updateDeploymentSpec(deploy, instance, binding)
+
+	return deploy
+}
+
+func updateDeploymentSpec(deploy *appsv1.Deployment, instance *v1beta1.HigressConsole, binding *controllerBinding) {
+	deploy.Spec = appsv1.DeploymentSpec{
+		Replicas: instance.Spec.Replicas,
+		Selector: &metav1.LabelSelector{
+			MatchLabels: instance.Spec.SelectorLabels,
+		},
+		Template: apiv1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      instance.Spec.SelectorLabels,
+				Annotations: instance.Spec.Annotations,
+			},
+			Spec: apiv1.PodSpec{
+				ImagePullSecrets:              instance.Spec.ImagePullSecrets,
+				SecurityContext:               instance.Spec.PodSecurityContext,
+				NodeSelector:                  instance.Spec.NodeSelector,
+				Affinity:                      instance.Spec.Affinity,
+				Tolerations:                   instance.Spec.Toleration,
+				TopologySpreadConstraints:     instance.Spec.TopologySpreadConstraints,
+				PriorityClassName:             instance.Spec.PriorityClassName,
+				SchedulerName:                 instance.Spec.SchedulerName,
+				RuntimeClassName:              instance.Spec.RuntimeClassName,
+				TerminationGracePeriodSeconds: instance.Spec.TerminationGracePeriodSeconds,
+				Containers: []apiv1.Container{
+					{
+						Name:            getContainerName(instance),
+						Image:           genImage(instance),
+						ImagePullPolicy: instance.Spec.Image.ImagePullPolicy,
+						SecurityContext: instance.Spec.SecurityContext,
+						Env:             genEnv(instance, binding),
+						Ports:           genPorts(instance),
+						ReadinessProbe:  genProbe(instance),
+					},
+				},
+			},
+		},
+	}
+
+	// resource
+	if instance.Spec.Resources != nil {
+		deploy.Spec.Template.Spec.Containers[0].Resources = *instance.Spec.Resources
+	}
+
+	// serviceAccount
+	if sa := instance.Spec.ServiceAccount; sa != nil && sa.Enable {
+		deploy.Spec.Template.Spec.ServiceAccountName = getServiceAccount(instance)
+	}
+}
+
+func genImage(instance *v1beta1.HigressConsole) string {
+	return controller.ImageReference(instance.Spec.Image.Repository, instance.Spec.Image.Tag)
+}
+
+func genPorts(instance *v1beta1.HigressConsole) []apiv1.ContainerPort {
+	if len(instance.Spec.Ports) > 0 {
+		return instance.Spec.Ports
+	}
+
+	return []apiv1.ContainerPort{
+		{
+			Name:          "http",
+			Protocol:      apiv1.ProtocolTCP,
+			ContainerPort: consolePort,
+		},
+	}
+}
+
+func genProbe(instance *v1beta1.HigressConsole) *apiv1.Probe {
+	if instance.Spec.ReadinessProbe != nil {
+		return instance.Spec.ReadinessProbe
+	}
+
+	return &apiv1.Probe{
+		ProbeHandler: apiv1.ProbeHandler{
+			TCPSocket: &apiv1.TCPSocketAction{
+				Port: intstr.FromInt(consolePort),
+			},
+		},
+		InitialDelaySeconds: 10,
+		PeriodSeconds:       5,
+		FailureThreshold:    6,
+		TimeoutSeconds:      3,
+	}
+}
+
+// genEnv points the console to its admin Secret and config ConfigMap, and to the ingress class and
+// the watched namespace of the controller it administers.
+func genEnv(instance *v1beta1.HigressConsole, binding *controllerBinding) []apiv1.EnvVar {
+	envs := []apiv1.EnvVar{
+		{
+			Name: "HIGRESS_CONSOLE_NS",
+			ValueFrom: &apiv1.EnvVarSource{
+				FieldRef: &apiv1.ObjectFieldSelector{
+					APIVersion: "v1",
+					FieldPath:  "metadata.namespace",
+				},
+			},
+		},
+		{
+			Name:  "HIGRESS_CONSOLE_SECRET_NAME",
+			Value: getSecretName(instance),
+		},
+		{
+			Name:  "HIGRESS_CONSOLE_CONFIG_MAP_NAME",
+			Value: getConfigMapName(instance),
+		},
+		{
+			Name:  "HIGRESS_CONSOLE_CONTROLLER_JWT_POLICY",
+			Value: binding.JwtPolicy,
+		},
+		{
+			Name:  "HIGRESS_CONSOLE_CONTROLLER_INGRESS_CLASS_NAME",
+			Value: binding.IngressClass,
+		},
+		{
+			Name:  "HIGRESS_CONSOLE_CONTROLLER_WATCHED_NAMESPACE",
+			Value: binding.WatchNamespace,
+		},
+		{
+			Name:  "HIGRESS_CONSOLE_CONTROLLER_SERVICE_HOST",
+			Value: fmt.Sprintf("%s.%s.svc", higresscontroller.HigressControllerServiceName, binding.Namespace),
+		},
+		{
+			Name:  "HIGRESS_CONSOLE_CONTROLLER_SERVICE_PORT",
+			Value: fmt.Sprint(controllerAPIPort),
+		},
+	}
+
+	return append(envs, instance.Spec.Env...)
+}
@@ -0,0 +1,369 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package higressconsole
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
+	. "github.com/alibaba/higress/higress-operator/internal/controller"
+)
+
+const (
+	finalizer = "higressconsole.higress.io/finalizer"
+)
+
+// reconcileStep creates or updates one kind of the objects managed for a HigressConsole.
+type reconcileStep struct {
+	name          string
+	failureReason string
+	run           func(context.Context, *operatorv1beta1.HigressConsole, logr.Logger) error
+}
+
+// HigressConsoleReconciler reconciles a HigressConsole object
+type HigressConsoleReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=operator.higress.io,resources=higressconsoles,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=operator.higress.io,resources=higressconsoles/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=operator.higress.io,resources=higressconsoles/finalizers,verbs=update
+//+kubebuilder:rbac:groups=operator.higress.io,resources=higresscontrollers,verbs=get;list;watch
+
+//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=services;configmaps;secrets;serviceaccounts;namespaces,verbs=create;update;get;list;watch;patch;delete
+
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings;roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
+
+// the console is granted the administered objects, which the operator must hold to grant them
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingressclasses,verbs=get;list;watch
+//+kubebuilder:rbac:groups="networking.higress.io",resources=mcpbridges;http2rpcs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="extensions.higress.io",resources=wasmplugins,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile deploys the console of a HigressConsole and points it to the HigressController it
+// administers.
+func (r *HigressConsoleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	instance := &operatorv1beta1.HigressConsole{}
+	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info(fmt.Sprintf("HigressConsole(%v) resource not found", req.NamespacedName))
+			return ctrl.Result{}, nil
+		}
+
+		logger.Error(err, "Failed to get resource HigressConsole(%v)", req.NamespacedName)
+		return ctrl.Result{}, err
+	}
+
+	// instances that were not admitted by the webhook
+	if recorded, err := RecordDefaultsVersion(ctx, r.Client, instance, instance.Status.Inventory,
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: instance.Namespace, Name: instance.Name}}, LatestDefaultsVersion); err != nil {
+		logger.Error(err, "Failed to record the defaults version")
+		return ctrl.Result{}, err
+	} else if recorded {
+		if err = r.Update(ctx, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// instances that were not defaulted by the webhook
+	SetDefaults(instance)
+
+	ctx = WithApplyOptions(ctx, ApplyOptions{
+		Owner:       instance,
+		Recorder:    r.Recorder,
+		DriftPolicy: instance.Spec.DriftPolicy,
+		// drifts can only be told apart from changes of the spec once the spec has been applied
+		DetectDrift: instance.Status.ObservedGeneration == instance.Generation,
+	})
+
+	// if deletionTimeStamp is not nil, it means is marked to be deleted
+	if instance.GetDeletionTimestamp() != nil {
+		if controllerutil.ContainsFinalizer(instance, finalizer) {
+			if err := r.finalizeHigressConsole(ctx, instance, logger); err != nil {
+				r.Recorder.Event(instance, apiv1.EventTypeWarning, ReasonFinalizeFailed, err.Error())
+				return ctrl.Result{}, err
+			}
+			r.Recorder.Eventf(instance, apiv1.EventTypeNormal, ReasonFinalized, "Finalized the instance with deletion policy %s", instance.Spec.DeletionPolicy)
+
+			controllerutil.RemoveFinalizer(instance, finalizer)
+
+			if err := r.Update(ctx, instance); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	// check if the namespace still exists during the reconciling
+	ns, nn := &apiv1.Namespace{}, types.NamespacedName{Name: instance.Namespace, Namespace: apiv1.NamespaceAll}
+	err := r.Get(ctx, nn, ns)
+	if (err != nil && errors.IsNotFound(err)) || (ns.Status.Phase == apiv1.NamespaceTerminating) {
+		logger.Info(fmt.Sprintf("The namespace (%s) doesn't exist or is in Terminating status, canceling Reconciling", instance.Namespace))
+		return ctrl.Result{}, nil
+	} else if err != nil {
+		logger.Error(err, "Failed to check if namespace exists")
+		return ctrl.Result{}, nil
+	}
+
+	// add finalizer for this CR
+	if controllerutil.AddFinalizer(instance, finalizer) {
+		if err = r.Update(ctx, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	var binding *controllerBinding
+	if err = ObserveStep("HigressConsole", StepControllerRef, func() (err error) {
+		binding, err = resolveControllerBinding(ctx, r.Client, instance)
+		return err
+	}); err != nil {
+		return r.reconcileFailed(ctx, instance, ReasonControllerRefFailed, err, logger)
+	}
+	ctx = withControllerBinding(ctx, binding)
+
+	for _, step := range []reconcileStep{
+		{StepServiceAccount, ReasonServiceAccountFailed, r.createServiceAccount},
+		{StepRBAC, ReasonRBACFailed, r.createRBAC},
+		{StepSecret, ReasonSecretFailed, r.createSecret},
+		{StepConfigMap, ReasonConfigMapFailed, r.createConfigMap},
+		{StepDeployment, ReasonDeploymentFailed, r.createDeployment},
+		{StepService, ReasonServiceFailed, r.createService},
+		{StepPrune, ReasonPruneFailed, r.prune},
+	} {
+		if err = ObserveStep("HigressConsole", step.name, func() error {
+			return step.run(ctx, instance, logger)
+		}); err != nil {
+			return r.reconcileFailed(ctx, instance, step.failureReason, err, logger)
+		}
+	}
+
+	ClearReconcileError(&instance.Status.Conditions, instance.Generation)
+	instance.Status.ObservedGeneration = instance.Generation
+	if err = r.updateStatus(ctx, instance); err != nil {
+		logger.Error(err, "Failed to update higressConsole/status")
+		return ctrl.Result{}, err
+	}
+
+	// objects that are not watched, such as the RBAC objects, are checked for drifts periodically
+	return ctrl.Result{RequeueAfter: DriftCheckInterval}, nil
+}
+
+// reconcileFailed records the failed step in the status and returns err to requeue the request.
+func (r *HigressConsoleReconciler) reconcileFailed(ctx context.Context, instance *operatorv1beta1.HigressConsole,
+	reason string, err error, logger logr.Logger) (ctrl.Result, error) {
+	r.Recorder.Event(instance, apiv1.EventTypeWarning, reason, err.Error())
+	SetReconcileError(&instance.Status.Conditions, instance.Generation, reason, err)
+	if statusErr := r.updateStatus(ctx, instance); statusErr != nil {
+		logger.Error(statusErr, "Failed to update higressConsole/status")
+	}
+	return ctrl.Result{}, err
+}
+
+// updateStatus refreshes the conditions derived from the console Deployment and the field
+// conflicts of the reconcile, and writes the status.
+func (r *HigressConsoleReconciler) updateStatus(ctx context.Context, instance *operatorv1beta1.HigressConsole) error {
+	SetFieldConflicts(&instance.Status.Conditions, instance.Generation, FieldConflicts(ctx))
+
+	deploy := &appsv1.Deployment{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(instance), deploy); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		deploy = nil
+	}
+
+	SetDeploymentConditions(&instance.Status.Conditions, instance.Generation, deploy)
+	return r.Status().Update(ctx, instance)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *HigressConsoleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&operatorv1beta1.HigressConsole{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&apiv1.Service{}).
+		Owns(&apiv1.ConfigMap{}).
+		Owns(&apiv1.Secret{}).
+		Owns(&apiv1.ServiceAccount{}).
+		Watches(&operatorv1beta1.HigressController{}, handler.EnqueueRequestsFromMapFunc(r.consolesOfController)).
+		Complete(r)
+}
+
+// consolesOfController returns the requests of the consoles that administer the HigressController
+// obj, including the consoles without a controllerRef in its namespace.
+func (r *HigressConsoleReconciler) consolesOfController(ctx context.Context, obj client.Object) []reconcile.Request {
+	consoles := &operatorv1beta1.HigressConsoleList{}
+	if err := r.List(ctx, consoles); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list higressConsoles")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, console := range consoles.Items {
+		ref := console.Spec.ControllerRef
+		if ref == nil {
+			if console.Namespace == obj.GetNamespace() {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&console)})
+			}
+			continue
+		}
+		if ref.Name != obj.GetName() {
+			continue
+		}
+		if ref.Namespace == obj.GetNamespace() || (ref.Namespace == "" && console.Namespace == obj.GetNamespace()) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&console)})
+		}
+	}
+	return requests
+}
+
+func (r *HigressConsoleReconciler) createServiceAccount(ctx context.Context, instance *operatorv1beta1.HigressConsole, logger logr.Logger) error {
+	if !instance.Spec.ServiceAccount.Enable {
+		return nil
+	}
+
+	sa := initServiceAccount(&apiv1.ServiceAccount{}, instance)
+	if err := ctrl.SetControllerReference(instance, sa, r.Scheme); err != nil {
+		return err
+	}
+
+	return Apply(ctx, r.Client, "ServiceAccount", sa, logger)
+}
+
+func (r *HigressConsoleReconciler) createRBAC(ctx context.Context, instance *operatorv1beta1.HigressConsole, logger logr.Logger) error {
+	if !instance.Spec.RBAC.Enable || !instance.Spec.ServiceAccount.Enable {
+		return nil
+	}
+
+	if err := Apply(ctx, r.Client, "clusterrole", initClusterRole(&rbacv1.ClusterRole{}, instance), logger); err != nil {
+		return err
+	}
+
+	if err := Apply(ctx, r.Client, "clusterRoleBinding", initClusterRoleBinding(&rbacv1.ClusterRoleBinding{}, instance), logger); err != nil {
+		return err
+	}
+
+	role := initRole(&rbacv1.Role{}, instance)
+	if err := ctrl.SetControllerReference(instance, role, r.Scheme); err != nil {
+		return err
+	}
+	if err := Apply(ctx, r.Client, "role", role, logger); err != nil {
+		return err
+	}
+
+	rb := initRoleBinding(&rbacv1.RoleBinding{}, instance)
+	if err := ctrl.SetControllerReference(instance, rb, r.Scheme); err != nil {
+		return err
+	}
+	return Apply(ctx, r.Client, "roleBinding", rb, logger)
+}
+
+// createSecret applies the admin Secret, whose generated credential is kept across the reconciles.
+func (r *HigressConsoleReconciler) createSecret(ctx context.Context, instance *operatorv1beta1.HigressConsole, logger logr.Logger) error {
+	secret := &apiv1.Secret{}
+	key := types.NamespacedName{Namespace: instance.Namespace, Name: getSecretName(instance)}
+	if err := r.Get(ctx, key, secret); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	secret, err := initSecret(secret, instance)
+	if err != nil {
+		return err
+	}
+	if err = ctrl.SetControllerReference(instance, secret, r.Scheme); err != nil {
+		return err
+	}
+
+	return Apply(ctx, r.Client, "Secret", secret, logger)
+}
+
+func (r *HigressConsoleReconciler) createConfigMap(ctx context.Context, instance *operatorv1beta1.HigressConsole, logger logr.Logger) error {
+	cm := initConfigMap(&apiv1.ConfigMap{}, instance)
+	if err := ctrl.SetControllerReference(instance, cm, r.Scheme); err != nil {
+		return err
+	}
+
+	return Apply(ctx, r.Client, "ConfigMap", cm, logger)
+}
+
+func (r *HigressConsoleReconciler) createDeployment(ctx context.Context, instance *operatorv1beta1.HigressConsole, logger logr.Logger) error {
+	deploy := initDeployment(&appsv1.Deployment{}, instance, getControllerBinding(ctx, instance))
+	if err := ctrl.SetControllerReference(instance, deploy, r.Scheme); err != nil {
+		return err
+	}
+
+	return Apply(ctx, r.Client, "Deployment", deploy, logger)
+}
+
+func (r *HigressConsoleReconciler) createService(ctx context.Context, instance *operatorv1beta1.HigressConsole, logger logr.Logger) error {
+	svc := initService(&apiv1.Service{}, instance)
+	if err := ctrl.SetControllerReference(instance, svc, r.Scheme); err != nil {
+		return err
+	}
+
+	return Apply(ctx, r.Client, "Service", svc, logger)
+}
+
+// finalizeHigressConsole deletes the objects of the instance, or keeps them running without the
+// instance if the deletion policy is Retain. Objects that are already gone are skipped, so that the
+// instance can always be deleted.
+func (r *HigressConsoleReconciler) finalizeHigressConsole(ctx context.Context, instance *operatorv1beta1.HigressConsole, logger logr.Logger) error {
+	if instance.Spec.DeletionPolicy == operatorv1beta1.DeletionPolicyRetain {
+		return Orphan(ctx, r.Client, instance, instance.Status.Inventory, logger)
+	}
+
+	if err := Prune(ctx, r.Client, instance.Status.Inventory, logger); err != nil {
+		return err
+	}
+
+	// the ClusterRoleBinding is missing from the inventory if the first reconcile failed after it
+	return DeleteClusterRBAC(ctx, r.Client, getClusterRoleName(instance), getClusterRoleName(instance), instance.Namespace, logger)
+}
+
+// prune deletes the objects of the inventory that were not applied in this reconcile and
+// records the new inventory.
+func (r *HigressConsoleReconciler) prune(ctx context.Context, instance *operatorv1beta1.HigressConsole, logger logr.Logger) error {
+	if err := Prune(ctx, r.Client, instance.Status.Inventory, logger); err != nil {
+		return err
+	}
+
+	instance.Status.Inventory = Inventory(ctx)
+	return nil
+}
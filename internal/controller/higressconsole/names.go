@@ -0,0 +1,44 @@
+package higressconsole
+
+import (
+	"fmt"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+// The names of the objects of a console are derived from the name of its CR, so that several
+// consoles can run in one namespace. A CR named higress-console gets the names of the helm chart.
+
+func getServiceName(instance *v1beta1.HigressConsole) string {
+	return instance.Name
+}
+
+func getContainerName(instance *v1beta1.HigressConsole) string {
+	return "higress-console"
+}
+
+func getRoleName(instance *v1beta1.HigressConsole) string {
+	return instance.Name
+}
+
+// getClusterRoleName returns the name of the ClusterRole and ClusterRoleBinding of instance, which
+// include the namespace so that the consoles in different namespaces don't share them.
+func getClusterRoleName(instance *v1beta1.HigressConsole) string {
+	return fmt.Sprintf("%s-%s", instance.Name, instance.Namespace)
+}
+
+// getSecretName returns the name of the Secret that holds the admin credential.
+func getSecretName(instance *v1beta1.HigressConsole) string {
+	return instance.Name
+}
+
+func getConfigMapName(instance *v1beta1.HigressConsole) string {
+	return instance.Name
+}
+
+func getServiceAccount(instance *v1beta1.HigressConsole) string {
+	if instance.Spec.ServiceAccount.Name != "" {
+		return instance.Spec.ServiceAccount.Name
+	}
+	return instance.Name
+}
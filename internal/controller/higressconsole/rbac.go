@@ -0,0 +1,123 @@
+package higressconsole
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+var readWrite = []string{"get", "list", "watch", "create", "update", "patch", "delete"}
+
+// clusterRules are the rules of the objects that the console administers in the namespaces
+// watched by the controller.
+func clusterRules() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{
+			Verbs:     readWrite,
+			APIGroups: []string{"networking.k8s.io"},
+			Resources: []string{"ingresses"},
+		},
+		{
+			Verbs:     []string{"get", "list", "watch"},
+			APIGroups: []string{"networking.k8s.io"},
+			Resources: []string{"ingressclasses"},
+		},
+		// tls certificates
+		{
+			Verbs:     readWrite,
+			APIGroups: []string{""},
+			Resources: []string{"secrets"},
+		},
+		{
+			Verbs:     []string{"get", "list", "watch"},
+			APIGroups: []string{""},
+			Resources: []string{"services", "namespaces"},
+		},
+		{
+			Verbs:     readWrite,
+			APIGroups: []string{"networking.higress.io"},
+			Resources: []string{"mcpbridges", "http2rpcs"},
+		},
+		{
+			Verbs:     readWrite,
+			APIGroups: []string{"extensions.higress.io"},
+			Resources: []string{"wasmplugins"},
+		},
+	}
+}
+
+// rules are the rules of the admin Secret and the config ConfigMap in the namespace of the console.
+func rules() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{
+			Verbs:     readWrite,
+			APIGroups: []string{""},
+			Resources: []string{"secrets", "configmaps"},
+		},
+	}
+}
+
+func initClusterRole(cr *rbacv1.ClusterRole, instance *operatorv1beta1.HigressConsole) *rbacv1.ClusterRole {
+	*cr = rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: getClusterRoleName(instance),
+		},
+		Rules: clusterRules(),
+	}
+	return cr
+}
+
+func initClusterRoleBinding(crb *rbacv1.ClusterRoleBinding, instance *operatorv1beta1.HigressConsole) *rbacv1.ClusterRoleBinding {
+	*crb = rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: getClusterRoleName(instance),
+		},
+		RoleRef: rbacv1.RoleRef{
+			Kind:     "ClusterRole",
+			Name:     getClusterRoleName(instance),
+			APIGroup: "rbac.authorization.k8s.io",
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      getServiceAccount(instance),
+				Namespace: instance.Namespace,
+			},
+		},
+	}
+	return crb
+}
+
+func initRole(r *rbacv1.Role, instance *operatorv1beta1.HigressConsole) *rbacv1.Role {
+	*r = rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getRoleName(instance),
+			Namespace: instance.Namespace,
+		},
+		Rules: rules(),
+	}
+	return r
+}
+
+func initRoleBinding(rb *rbacv1.RoleBinding, instance *operatorv1beta1.HigressConsole) *rbacv1.RoleBinding {
+	*rb = rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getRoleName(instance),
+			Namespace: instance.Namespace,
+		},
+		RoleRef: rbacv1.RoleRef{
+			Kind:     "Role",
+			Name:     getRoleName(instance),
+			APIGroup: "rbac.authorization.k8s.io",
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      getServiceAccount(instance),
+				Namespace: instance.Namespace,
+			},
+		},
+	}
+	return rb
+}
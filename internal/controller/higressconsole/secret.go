@@ -0,0 +1,76 @@
+package higressconsole
+
+import (
+	"crypto/rand"
+	"math/big"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+// Keys of the admin Secret, the key and iv encrypt the login sessions of the console.
+const (
+	secretKeyAdminUsername = "adminUsername"
+	secretKeyAdminPassword = "adminPassword"
+	secretKeyKey           = "key"
+	secretKeyIV            = "iv"
+)
+
+const alphanumeric = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// generatedKeys are the keys of the admin Secret generated once, with the length of their values.
+var generatedKeys = []struct {
+	key    string
+	length int
+}{
+	{secretKeyAdminPassword, 8},
+	{secretKeyKey, 32},
+	{secretKeyIV, 16},
+}
+
+// initSecret renders the admin Secret of instance. The generated values of the live Secret are
+// kept, as the console changes the password when the admin logs in for the first time.
+func initSecret(secret *apiv1.Secret, instance *v1beta1.HigressConsole) (*apiv1.Secret, error) {
+	live := secret.Data
+	*secret = apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getSecretName(instance),
+			Namespace: instance.Namespace,
+			Labels:    instance.Labels,
+		},
+		Type: apiv1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			secretKeyAdminUsername: []byte(instance.Spec.AdminUsername),
+		},
+	}
+
+	for _, generated := range generatedKeys {
+		if value := live[generated.key]; len(value) > 0 {
+			secret.Data[generated.key] = value
+			continue
+		}
+
+		value, err := randomString(generated.length)
+		if err != nil {
+			return nil, err
+		}
+		secret.Data[generated.key] = []byte(value)
+	}
+
+	return secret, nil
+}
+
+func randomString(length int) (string, error) {
+	max := big.NewInt(int64(len(alphanumeric)))
+	b := make([]byte, length)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = alphanumeric[n.Int64()]
+	}
+	return string(b), nil
+}
@@ -0,0 +1,42 @@
+package higressconsole
+
+import (
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+func TestInitSecret(t *testing.T) {
+	instance := &v1beta1.HigressConsole{ObjectMeta: metav1.ObjectMeta{Name: "higress-console", Namespace: "tenant"}}
+	instance.Spec.AdminUsername = "admin"
+
+	secret, err := initSecret(&apiv1.Secret{}, instance)
+	if err != nil {
+		t.Fatalf("initSecret() error = %v", err)
+	}
+	for _, generated := range generatedKeys {
+		if got := len(secret.Data[generated.key]); got != generated.length {
+			t.Errorf("%s has %d bytes, want %d", generated.key, got, generated.length)
+		}
+	}
+
+	// the console changes the password, which the next reconciles keep, while the username follows the spec
+	live := secret.DeepCopy()
+	live.Data[secretKeyAdminPassword] = []byte("changed")
+	instance.Spec.AdminUsername = "root"
+	secret, err = initSecret(live.DeepCopy(), instance)
+	if err != nil {
+		t.Fatalf("initSecret() error = %v", err)
+	}
+	if got := string(secret.Data[secretKeyAdminUsername]); got != "root" {
+		t.Errorf("adminUsername = %q, want root", got)
+	}
+	for _, generated := range generatedKeys {
+		if got, want := string(secret.Data[generated.key]), string(live.Data[generated.key]); got != want {
+			t.Errorf("%s = %q, want the live %q", generated.key, got, want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package higressconsole
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+func initService(svc *apiv1.Service, instance *v1beta1.HigressConsole) *apiv1.Service {
+	*svc = apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getServiceName(instance),
+			Namespace: instance.Namespace,
+			Labels:    instance.Labels,
+		},
+	}
+
+	updateServiceSpec(svc, instance)
+	return svc
+}
+
+func updateServiceSpec(svc *apiv1.Service, instance *v1beta1.HigressConsole) {
+	svc.Spec.Selector = instance.Spec.SelectorLabels
+	svc.Spec.Type = apiv1.ServiceTypeClusterIP
+	if s := instance.Spec.Service; s != nil {
+		if s.Type != "" {
+			svc.Spec.Type = apiv1.ServiceType(s.Type)
+		}
+		if len(s.Annotations) > 0 {
+			svc.Annotations = s.Annotations
+		}
+		if ip := s.LoadBalancerIP; ip != "" {
+			svc.Spec.LoadBalancerIP = ip
+		}
+		if ranges := s.LoadBalancerSourceRanges; len(ranges) > 0 {
+			svc.Spec.LoadBalancerSourceRanges = ranges
+		}
+		svc.Spec.Ports = s.Ports
+	}
+}
@@ -0,0 +1,21 @@
+package higressconsole
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+func initServiceAccount(sa *apiv1.ServiceAccount, instance *v1beta1.HigressConsole) *apiv1.ServiceAccount {
+	*sa = apiv1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   instance.Namespace,
+			Name:        getServiceAccount(instance),
+			Labels:      instance.Labels,
+			Annotations: instance.Spec.ServiceAccount.Annotations,
+		},
+	}
+
+	return sa
+}
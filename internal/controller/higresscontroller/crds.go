@@ -1,73 +1,113 @@
 package higresscontroller
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"io"
-	"os"
+	"time"
 
-	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	"k8s.io/apimachinery/pkg/util/yaml"
+	apixv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apixv1client "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
+	"github.com/alibaba/higress/higress-operator/internal/controller"
+	"github.com/alibaba/higress/higress-operator/internal/controller/crds"
 )
 
 const (
-	crdsPath          = "./config/crd/higress"
-	decoderBufferSize = 4096
+	crdEstablishInterval = time.Second
+	crdEstablishTimeout  = 30 * time.Second
 )
 
-func getCRDsManifests() ([]string, error) {
-	files, err := os.ReadDir(crdsPath)
+// getCRDs returns the embedded CRDs of the Higress release of the controller image.
+func getCRDs(instance *operatorv1beta1.HigressController) ([]*apixv1.CustomResourceDefinition, error) {
+	release, err := crds.Select(instance.Spec.Controller.Image.Tag)
 	if err != nil {
 		return nil, err
 	}
 
-	var manifests []string
-	for _, f := range files {
-		manifests = append(manifests, fmt.Sprintf("%v/%v", crdsPath, f.Name()))
+	return crds.Get(release)
+}
+
+// isNewer returns true if the live CRD existing belongs to a newer release than crd, according to
+// the Higress release or the Gateway API bundle version annotations. CRDs that were not installed
+// by the operator are newer if they serve versions that crd doesn't.
+func isNewer(existing, crd *apixv1.CustomResourceDefinition) bool {
+	for _, annotation := range []string{crds.AnnotationRelease, crds.AnnotationGatewayAPIBundleVersion} {
+		if live, err := version.ParseGeneric(existing.Annotations[annotation]); err == nil {
+			if ours, err := version.ParseGeneric(crd.Annotations[annotation]); err == nil {
+				return ours.LessThan(live)
+			}
+		}
 	}
 
-	return manifests, nil
+	served := make(map[string]struct{}, len(crd.Spec.Versions))
+	for _, v := range crd.Spec.Versions {
+		served[v.Name] = struct{}{}
+	}
+	for _, v := range existing.Spec.Versions {
+		if _, ok := served[v.Name]; v.Served && !ok {
+			return true
+		}
+	}
+	return false
 }
 
-func getCRDs() ([]*v1.CustomResourceDefinition, error) {
-	manifests, err := getCRDsManifests()
+// applyCRD applies crd with a server-side apply, so that only the rendered fields are compared
+// with the live CRD and the server defaults don't cause an update. The fields of the older
+// operator releases and of the tools that installed the CRD are taken over.
+func applyCRD(ctx context.Context, cli apixv1client.CustomResourceDefinitionInterface, crd *apixv1.CustomResourceDefinition) (*apixv1.CustomResourceDefinition, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(crd)
 	if err != nil {
 		return nil, err
 	}
+	delete(content, "status")
+	unstructured.RemoveNestedField(content, "metadata", "creationTimestamp")
+	content["apiVersion"] = apixv1.SchemeGroupVersion.String()
+	content["kind"] = "CustomResourceDefinition"
 
-	var crds []*v1.CustomResourceDefinition
-
-	resolveManifest := func(path string) (err error) {
-		var f *os.File
-		f, err = os.Open(path)
-		defer func() {
-			if _err_ := f.Close(); _err_ != nil {
-				err = _err_
-			}
-		}()
-
-		if err != nil {
-			return fmt.Errorf("failed to open the CRD manifest %v: %w", path, err)
-		}
+	data, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	force := true
+	return cli.Patch(ctx, crd.Name, types.ApplyPatchType, data, metav1.PatchOptions{FieldManager: controller.FieldManager, Force: &force})
+}
 
-		dec := yaml.NewYAMLOrJSONDecoder(f, decoderBufferSize)
-		for {
-			var crd v1.CustomResourceDefinition
-			if err := dec.Decode(&crd); err != nil {
-				if err != io.EOF {
-					return fmt.Errorf("failed to parse the CRD manifest %v: %w", path, err)
-				}
-				break
-			}
-			crds = append(crds, &crd)
+func isEstablished(crd *apixv1.CustomResourceDefinition) bool {
+	for _, cond := range crd.Status.Conditions {
+		if cond.Type == apixv1.Established {
+			return cond.Status == apixv1.ConditionTrue
 		}
-		return
 	}
+	return false
+}
 
-	for _, path := range manifests {
-		if err = resolveManifest(path); err != nil {
-			return nil, err
+// waitForCRDs waits until the CRDs names are established, so that the controller doesn't start
+// before it can watch them.
+func waitForCRDs(ctx context.Context, cli apixv1client.CustomResourceDefinitionInterface, names []string) error {
+	var pending string
+	err := wait.PollUntilContextTimeout(ctx, crdEstablishInterval, crdEstablishTimeout, true, func(ctx context.Context) (bool, error) {
+		for _, name := range names {
+			crd, err := cli.Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return false, err
+			}
+			if !isEstablished(crd) {
+				pending = name
+				return false, nil
+			}
 		}
+		return true, nil
+	})
+	if err != nil && pending != "" {
+		return fmt.Errorf("CRD %v is not established: %w", pending, err)
 	}
-
-	return crds, nil
+	return err
 }
@@ -0,0 +1,59 @@
+package higresscontroller
+
+import (
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
+	. "github.com/alibaba/higress/higress-operator/internal/controller"
+)
+
+// defaults lists the defaults of every defaults version, the defaults of version n are applied
+// before the ones of the older versions, so that they take precedence. A change of the defaults has
+// to be added as a new version.
+var defaults = []func(instance *operatorv1beta1.HigressController){
+	defaultsV1,
+}
+
+// LatestDefaultsVersion is the version of the defaults of the new instances.
+var LatestDefaultsVersion = len(defaults)
+
+// SetDefaults fills the unset fields of instance with the defaults of its defaults version.
+func SetDefaults(instance *operatorv1beta1.HigressController) {
+	version := DefaultsVersion(instance)
+	if version > len(defaults) {
+		version = len(defaults)
+	}
+	for i := version - 1; i >= 0; i-- {
+		defaults[i](instance)
+	}
+}
+
+func defaultsV1(instance *operatorv1beta1.HigressController) {
+	if instance.Spec.RBAC == nil {
+		instance.Spec.RBAC = &operatorv1beta1.RBAC{Enable: true}
+	}
+	// serviceAccount
+	if instance.Spec.ServiceAccount == nil {
+		instance.Spec.ServiceAccount = &operatorv1beta1.ServiceAccount{Enable: true, Name: "higress-controller"}
+	}
+	// driftPolicy
+	if instance.Spec.DriftPolicy == "" {
+		instance.Spec.DriftPolicy = operatorv1beta1.DriftPolicyEnforce
+	}
+	// deletionPolicy
+	if instance.Spec.DeletionPolicy == "" {
+		instance.Spec.DeletionPolicy = operatorv1beta1.DeletionPolicyDelete
+	}
+	// crdInstallPolicy
+	if instance.Spec.CRDInstallPolicy == "" {
+		instance.Spec.CRDInstallPolicy = operatorv1beta1.CRDInstallPolicyInstall
+	}
+	// gatewayAPI
+	if instance.Spec.GatewayAPI != nil && instance.Spec.GatewayAPI.Channel == "" {
+		instance.Spec.GatewayAPI.Channel = operatorv1beta1.GatewayAPIChannelStandard
+	}
+	// SelectorLabels
+	if len(instance.Spec.SelectorLabels) == 0 {
+		instance.Spec.SelectorLabels = map[string]string{
+			"app": "higress-controller",
+		}
+	}
+}
@@ -9,9 +9,8 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	operatorv1alpha1 "github.com/alibaba/higress/higress-operator/api/v1alpha1"
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
 	"github.com/alibaba/higress/higress-operator/internal/controller"
 )
 
@@ -20,7 +19,9 @@ const (
 	DiscoveryName   = "discovery"
 )
 
-func initDeployment(deploy *appsv1.Deployment, instance *operatorv1alpha1.HigressController) *appsv1.Deployment {
+// initDeployment renders the Deployment of instance, whose pilot mounts the gateway config named
+// gatewayConfig.
+func initDeployment(deploy *appsv1.Deployment, instance *operatorv1beta1.HigressController, gatewayConfig string) *appsv1.Deployment {
 	*deploy = appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      instance.Name,
@@ -29,28 +30,59 @@ func initDeployment(deploy *appsv1.Deployment, instance *operatorv1alpha1.Higres
 		},
 	}
 
-	updateDeploymentSpec(deploy, instance)
+	updateDeploymentSpec(deploy, instance, gatewayConfig)
 	return deploy
 }
 
-func updateDeploymentSpec(deploy *appsv1.Deployment, instance *operatorv1alpha1.HigressController) {
-	deploy.Spec.Selector = &metav1.LabelSelector{MatchLabels: instance.Spec.SelectorLabels}
-
-	deploy.Spec.Replicas = instance.Spec.Replicas
+// updateDeploymentSpec rebuilds the whole spec of the Deployment from instance, so that changes
+// of the containers are rolled out and containers that are no longer wanted are removed.
+func updateDeploymentSpec(deploy *appsv1.Deployment, instance *operatorv1beta1.HigressController, gatewayConfig string) {
+	// the replicas are left out of the apply with an autoscaler, so that the operator never owns them
+	replicas := instance.Spec.Replicas
+	if controller.AutoScalingEnabled(&instance.Spec.CRDCommonFields) {
+		replicas = nil
+	}
+
+	deploy.Spec = appsv1.DeploymentSpec{
+		Replicas: replicas,
+		Selector: &metav1.LabelSelector{
+			MatchLabels: instance.Spec.SelectorLabels,
+		},
+		Template: apiv1.PodTemplateSpec{
+			Spec: apiv1.PodSpec{
+				ServiceAccountName:            getServiceAccount(instance),
+				ImagePullSecrets:              genImagePullSecrets(instance),
+				NodeSelector:                  instance.Spec.NodeSelector,
+				Affinity:                      instance.Spec.Affinity,
+				Tolerations:                   instance.Spec.Toleration,
+				TopologySpreadConstraints:     instance.Spec.TopologySpreadConstraints,
+				PriorityClassName:             instance.Spec.PriorityClassName,
+				SchedulerName:                 instance.Spec.SchedulerName,
+				RuntimeClassName:              instance.Spec.RuntimeClassName,
+				TerminationGracePeriodSeconds: instance.Spec.TerminationGracePeriodSeconds,
+				Containers:                    genContainers(instance),
+				Volumes:                       genVolumes(instance, gatewayConfig),
+			},
+		},
+	}
 
 	controller.UpdateObjectMeta(&deploy.Spec.Template.ObjectMeta, instance, instance.Spec.SelectorLabels)
+}
 
-	deploy.Spec.Template.Spec.ServiceAccountName = getServiceAccount(instance)
-
-	exist := false
-	for _, c := range deploy.Spec.Template.Spec.Containers {
-		if c.Name == genControllerName(instance) {
-			exist = true
-			break
-		}
+func genImagePullSecrets(instance *operatorv1beta1.HigressController) []apiv1.LocalObjectReference {
+	secrets := append([]apiv1.LocalObjectReference{}, instance.Spec.Controller.ImagePullSecrets...)
+	if !instance.Spec.EnableHigressIstio {
+		secrets = append(secrets, instance.Spec.Pilot.ImagePullSecrets...)
 	}
-	if !exist {
-		deploy.Spec.Template.Spec.Containers = append(deploy.Spec.Template.Spec.Containers, apiv1.Container{
+	if len(secrets) == 0 {
+		return nil
+	}
+	return secrets
+}
+
+func genContainers(instance *operatorv1beta1.HigressController) []apiv1.Container {
+	containers := []apiv1.Container{
+		{
 			Name:            genControllerName(instance),
 			Image:           genImage(instance.Spec.Controller.Image.Repository, instance.Spec.Controller.Image.Tag),
 			ImagePullPolicy: instance.Spec.Controller.Image.ImagePullPolicy,
@@ -58,16 +90,20 @@ func updateDeploymentSpec(deploy *appsv1.Deployment, instance *operatorv1alpha1.
 			Ports:           genControllerPorts(instance),
 			SecurityContext: genControllerSecurityContext(instance),
 			Env:             genControllerEnv(instance),
+			ReadinessProbe:  instance.Spec.Controller.ReadinessProbe,
 			VolumeMounts:    genControllerVolumeMounts(instance),
-		})
+		},
+	}
+	if resources := instance.Spec.Controller.Resources; resources != nil {
+		containers[0].Resources = *resources
 	}
 
-	deploy.Spec.Template.Spec.Volumes = genVolumes(instance)
-
+	// pilot is provided by istio if enableHigressIstio is set
 	if !instance.Spec.EnableHigressIstio {
 		pilot := apiv1.Container{
 			Name:            genPilotName(instance),
 			Image:           genImage(instance.Spec.Pilot.Image.Repository, instance.Spec.Pilot.Image.Tag),
+			ImagePullPolicy: instance.Spec.Pilot.Image.ImagePullPolicy,
 			Args:            genPilotArgs(instance),
 			Ports:           genPilotPorts(instance),
 			SecurityContext: genPilotSecurityContext(instance),
@@ -75,39 +111,27 @@ func updateDeploymentSpec(deploy *appsv1.Deployment, instance *operatorv1alpha1.
 			ReadinessProbe:  genPilotProbe(instance),
 			VolumeMounts:    genPilotVolumeMounts(instance),
 		}
-
-		exist = false
-		for _, c := range deploy.Spec.Template.Spec.Containers {
-			if c.Name == genPilotName(instance) {
-				exist = true
-				break
-			}
-		}
-		if !exist {
-			deploy.Spec.Template.Spec.Containers = append(deploy.Spec.Template.Spec.Containers, pilot)
+		if resources := instance.Spec.Pilot.Resources; resources != nil {
+			pilot.Resources = *resources
 		}
+		containers = append(containers, pilot)
 	}
-}
 
-func muteDeployment(deploy *appsv1.Deployment, instance *operatorv1alpha1.HigressController) controllerutil.MutateFn {
-	return func() error {
-		updateDeploymentSpec(deploy, instance)
-		return nil
-	}
+	return containers
 }
 
 func genImage(repository string, tag string) string {
-	return fmt.Sprintf("%v:%v", repository, tag)
+	return controller.ImageReference(repository, tag)
 }
 
-func genPilotName(instance *operatorv1alpha1.HigressController) string {
+func genPilotName(instance *operatorv1beta1.HigressController) string {
 	if instance.Spec.Pilot.Name != "" {
 		return instance.Spec.Pilot.Name
 	}
 	return DiscoveryName
 }
 
-func genPilotProbe(instance *operatorv1alpha1.HigressController) *apiv1.Probe {
+func genPilotProbe(instance *operatorv1beta1.HigressController) *apiv1.Probe {
 	pilot := instance.Spec.Pilot
 
 	if pilot.ReadinessProbe != nil {
@@ -127,7 +151,7 @@ func genPilotProbe(instance *operatorv1alpha1.HigressController) *apiv1.Probe {
 	}
 }
 
-func genPilotEnv(instance *operatorv1alpha1.HigressController) []apiv1.EnvVar {
+func genPilotEnv(instance *operatorv1beta1.HigressController) []apiv1.EnvVar {
 	pilot := instance.Spec.Pilot
 
 	envs := []apiv1.EnvVar{
@@ -227,14 +251,22 @@ func genPilotEnv(instance *operatorv1alpha1.HigressController) []apiv1.EnvVar {
 		envs = append(envs, apiv1.EnvVar{Name: "CUSTOM_CA_CERT_NAME", Value: "higress-ca-root-cert"})
 	}
 
-	for k, v := range instance.Spec.Pilot.Env {
-		envs = append(envs, apiv1.EnvVar{Name: k, Value: v})
+	if gatewayAPI := instance.Spec.GatewayAPI; gatewayAPI != nil && gatewayAPI.Enable {
+		envs = append(envs, apiv1.EnvVar{Name: "PILOT_ENABLE_GATEWAY_API", Value: "true"})
+		envs = append(envs, apiv1.EnvVar{Name: "PILOT_ENABLE_GATEWAY_API_STATUS", Value: "true"})
+		// the gateways are deployed by the operator instead of pilot
+		envs = append(envs, apiv1.EnvVar{Name: "PILOT_ENABLE_GATEWAY_API_DEPLOYMENT_CONTROLLER", Value: "false"})
+		if gatewayAPI.Channel == operatorv1beta1.GatewayAPIChannelExperimental {
+			envs = append(envs, apiv1.EnvVar{Name: "PILOT_ENABLE_ALPHA_GATEWAY_API", Value: "true"})
+		}
 	}
 
+	envs = append(envs, instance.Spec.Pilot.Env...)
+
 	return envs
 }
 
-func genPilotSecurityContext(instance *operatorv1alpha1.HigressController) *apiv1.SecurityContext {
+func genPilotSecurityContext(instance *operatorv1beta1.HigressController) *apiv1.SecurityContext {
 	pilot := instance.Spec.Pilot
 
 	if pilot.SecurityContext != nil {
@@ -256,7 +288,7 @@ func genPilotSecurityContext(instance *operatorv1alpha1.HigressController) *apiv
 	}
 }
 
-func genPilotPorts(instance *operatorv1alpha1.HigressController) []apiv1.ContainerPort {
+func genPilotPorts(instance *operatorv1beta1.HigressController) []apiv1.ContainerPort {
 	pilot := instance.Spec.Pilot
 
 	if len(pilot.Ports) != 0 {
@@ -279,7 +311,7 @@ func genPilotPorts(instance *operatorv1alpha1.HigressController) []apiv1.Contain
 	}
 }
 
-func genPilotArgs(instance *operatorv1alpha1.HigressController) []string {
+func genPilotArgs(instance *operatorv1beta1.HigressController) []string {
 	pilot := instance.Spec.Pilot
 
 	var args []string
@@ -304,7 +336,7 @@ func genPilotArgs(instance *operatorv1alpha1.HigressController) []string {
 	return args
 }
 
-func genPilotVolumeMounts(instance *operatorv1alpha1.HigressController) []apiv1.VolumeMount {
+func genPilotVolumeMounts(instance *operatorv1beta1.HigressController) []apiv1.VolumeMount {
 	vms := []apiv1.VolumeMount{
 		{
 			Name:      "config",
@@ -343,19 +375,25 @@ func genPilotVolumeMounts(instance *operatorv1alpha1.HigressController) []apiv1.
 	return vms
 }
 
-func genControllerName(instance *operatorv1alpha1.HigressController) string {
+func genControllerName(instance *operatorv1beta1.HigressController) string {
 	if instance.Spec.Controller.Name != "" {
 		return instance.Spec.Controller.Name
 	}
 	return HigressCoreName
 }
 
-func genControllerArgs(instance *operatorv1alpha1.HigressController) []string {
+// GatewaySelectorValue returns the value of the GatewaySelectorKey label of the gateway pods that
+// instance serves.
+func GatewaySelectorValue(instance *operatorv1beta1.HigressController) string {
+	return fmt.Sprintf("%v-%v", instance.Namespace, instance.Spec.Controller.GatewayName)
+}
+
+func genControllerArgs(instance *operatorv1beta1.HigressController) []string {
 	var args []string
 
 	args = append(args, "serve")
-	args = append(args, fmt.Sprintf("--gatewaySelectorKey=higress"))
-	args = append(args, fmt.Sprintf("--gatewaySelectorValue=%v-%v", instance.Namespace, instance.Spec.Controller.GatewayName))
+	args = append(args, fmt.Sprintf("--gatewaySelectorKey=%v", GatewaySelectorKey))
+	args = append(args, fmt.Sprintf("--gatewaySelectorValue=%v", GatewaySelectorValue(instance)))
 	args = append(args, fmt.Sprintf("--ingressClass=%v", instance.Spec.Controller.IngressClass))
 
 	if !instance.Spec.EnableStatus {
@@ -364,18 +402,21 @@ func genControllerArgs(instance *operatorv1alpha1.HigressController) []string {
 	if instance.Spec.Controller.WatchNamespace != "" {
 		args = append(args, fmt.Sprintf("--watchNamespace=%v", instance.Spec.Controller.WatchNamespace))
 	}
+	if gatewayAPI := instance.Spec.GatewayAPI; gatewayAPI != nil && gatewayAPI.Enable {
+		args = append(args, "--enableGatewayAPI=true")
+	}
 
 	return args
 }
 
-func genControllerSecurityContext(instance *operatorv1alpha1.HigressController) *apiv1.SecurityContext {
+func genControllerSecurityContext(instance *operatorv1beta1.HigressController) *apiv1.SecurityContext {
 	if instance.Spec.Controller.SecurityContext != nil {
 		return instance.Spec.Controller.SecurityContext
 	}
 	return &apiv1.SecurityContext{}
 }
 
-func genControllerEnv(instance *operatorv1alpha1.HigressController) []apiv1.EnvVar {
+func genControllerEnv(instance *operatorv1beta1.HigressController) []apiv1.EnvVar {
 	envs := []apiv1.EnvVar{
 		{
 			Name: "POD_NAMESPACE",
@@ -395,14 +436,12 @@ func genControllerEnv(instance *operatorv1alpha1.HigressController) []apiv1.EnvV
 		},
 	}
 
-	for k, v := range instance.Spec.Controller.Env {
-		envs = append(envs, apiv1.EnvVar{Name: k, Value: v})
-	}
+	envs = append(envs, instance.Spec.Controller.Env...)
 
 	return envs
 }
 
-func genControllerPorts(instance *operatorv1alpha1.HigressController) []apiv1.ContainerPort {
+func genControllerPorts(instance *operatorv1beta1.HigressController) []apiv1.ContainerPort {
 	if len(instance.Spec.Controller.Ports) != 0 {
 		return instance.Spec.Controller.Ports
 	}
@@ -421,7 +460,7 @@ func genControllerPorts(instance *operatorv1alpha1.HigressController) []apiv1.Co
 	}
 }
 
-func genControllerVolumeMounts(instance *operatorv1alpha1.HigressController) []apiv1.VolumeMount {
+func genControllerVolumeMounts(instance *operatorv1beta1.HigressController) []apiv1.VolumeMount {
 	return []apiv1.VolumeMount{
 		{
 			Name:      "log",
@@ -430,7 +469,7 @@ func genControllerVolumeMounts(instance *operatorv1alpha1.HigressController) []a
 	}
 }
 
-func genVolumes(instance *operatorv1alpha1.HigressController) []apiv1.Volume {
+func genVolumes(instance *operatorv1beta1.HigressController, gatewayConfig string) []apiv1.Volume {
 	optional := true
 	defaultMode := int32(420)
 	volumes := []apiv1.Volume{
@@ -476,7 +515,7 @@ func genVolumes(instance *operatorv1alpha1.HigressController) []apiv1.Volume {
 			VolumeSource: apiv1.VolumeSource{
 				ConfigMap: &apiv1.ConfigMapVolumeSource{
 					LocalObjectReference: apiv1.LocalObjectReference{
-						Name: controller.HigressGatewayConfig,
+						Name: gatewayConfig,
 					},
 				},
 			},
@@ -518,3 +557,13 @@ func genVolumes(instance *operatorv1alpha1.HigressController) []apiv1.Volume {
 
 	return volumes
 }
+
+// mountedGatewayConfig returns the name of the gateway config mounted by deploy, or an empty name.
+func mountedGatewayConfig(deploy *appsv1.Deployment) string {
+	for _, volume := range deploy.Spec.Template.Spec.Volumes {
+		if volume.Name == "config" && volume.ConfigMap != nil {
+			return volume.ConfigMap.Name
+		}
+	}
+	return ""
+}
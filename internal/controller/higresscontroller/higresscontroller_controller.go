@@ -18,37 +18,56 @@ package higresscontroller
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apixv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apixv1client "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1"
-	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	operatorv1alpha1 "github.com/alibaba/higress/higress-operator/api/v1alpha1"
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
 	. "github.com/alibaba/higress/higress-operator/internal/controller"
+	"github.com/alibaba/higress/higress-operator/internal/controller/crds"
 )
 
 const (
 	finalizer = "higresscontroller.higress.io/finalizer"
 )
 
+// reconcileStep creates or updates one kind of the objects managed for a HigressController.
+type reconcileStep struct {
+	name          string
+	failureReason string
+	run           func(context.Context, *operatorv1beta1.HigressController, logr.Logger) error
+}
+
 // HigressControllerReconciler reconciles a HigressController object
 type HigressControllerReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
-	Config *rest.Config
+	Scheme   *runtime.Scheme
+	Config   *rest.Config
+	Recorder record.EventRecorder
+
+	// pdbVersion is the policy API version served by the cluster, resolved by SetupWithManager.
+	pdbVersion string
 }
 
 //+kubebuilder:rbac:groups=operator.higress.io,resources=higresscontrollers,verbs=get;list;watch;create;update;patch;delete
@@ -100,10 +119,12 @@ type HigressControllerReconciler struct {
 //+kubebuilder:rbac:groups="networking.x-k8s.io",resources=*,verbs=get;list;watch;update
 
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=list;watch;get
-//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingressclasses,verbs=get;create;delete
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingressclasses,verbs=get;create;update;patch;delete
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gatewayclasses,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses/status,verbs=update
 //+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;create;delete;update;watch;list
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings;roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -117,7 +138,7 @@ type HigressControllerReconciler struct {
 func (r *HigressControllerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
-	instance := &operatorv1alpha1.HigressController{}
+	instance := &operatorv1beta1.HigressController{}
 	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
 		if errors.IsNotFound(err) {
 			logger.Info(fmt.Sprintf("HigressController(%v) resource not found. Ignoring since object must be deleted", req.NamespacedName))
@@ -128,14 +149,36 @@ func (r *HigressControllerReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, err
 	}
 
-	r.setDefaultValues(instance)
+	// instances that were not admitted by the webhook
+	if recorded, err := RecordDefaultsVersion(ctx, r.Client, instance, instance.Status.Inventory,
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: instance.Namespace, Name: instance.Name}}, LatestDefaultsVersion); err != nil {
+		logger.Error(err, "Failed to record the defaults version")
+		return ctrl.Result{}, err
+	} else if recorded {
+		if err = r.Update(ctx, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// instances that were not defaulted by the webhook
+	SetDefaults(instance)
+
+	ctx = WithApplyOptions(ctx, ApplyOptions{
+		Owner:       instance,
+		Recorder:    r.Recorder,
+		DriftPolicy: instance.Spec.DriftPolicy,
+		// drifts can only be told apart from changes of the spec once the spec has been applied
+		DetectDrift: instance.Status.ObservedGeneration == instance.Generation,
+	})
 
 	// if DeletionTimestamp is not nil, it means is marked to be deleted
 	if instance.GetDeletionTimestamp() != nil {
 		if controllerutil.ContainsFinalizer(instance, finalizer) {
-			if err := r.finalizeHigressController(instance, logger); err != nil {
+			if err := r.finalizeHigressController(ctx, instance, logger); err != nil {
+				r.Recorder.Event(instance, apiv1.EventTypeWarning, ReasonFinalizeFailed, err.Error())
 				return ctrl.Result{}, err
 			}
+			r.Recorder.Eventf(instance, apiv1.EventTypeNormal, ReasonFinalized, "Finalized the instance with deletion policy %s", instance.Spec.DeletionPolicy)
 
 			controllerutil.RemoveFinalizer(instance, finalizer)
 
@@ -165,86 +208,120 @@ func (r *HigressControllerReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		}
 	}
 
-	if err = r.createCRDs(ctx, logger); err != nil {
-		logger.Error(err, "Failed to create crds")
-		return ctrl.Result{}, err
+	for _, step := range []reconcileStep{
+		{StepIngressClass, ReasonIngressClassConflict, r.checkIngressClass},
+		{StepCRDs, ReasonCRDsFailed, r.createCRDs},
+		{StepGatewayAPI, ReasonGatewayAPIFailed, r.createGatewayAPI},
+		{StepServiceAccount, ReasonServiceAccountFailed, r.createServiceAccount},
+		{StepRBAC, ReasonRBACFailed, r.createRBAC},
+		{StepDeployment, ReasonDeploymentFailed, r.createDeployment},
+		{StepService, ReasonServiceFailed, r.createService},
+		{StepDisruption, ReasonDisruptionFailed, r.createPDB},
+		{StepClasses, ReasonClassesFailed, r.createClasses},
+		{StepPrune, ReasonPruneFailed, r.prune},
+	} {
+		if err = ObserveStep("HigressController", step.name, func() error {
+			return step.run(ctx, instance, logger)
+		}); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to reconcile %s", step.name))
+			return r.reconcileFailed(ctx, instance, step.failureReason, err, logger)
+		}
 	}
 
-	if err = r.createServiceAccount(ctx, instance, logger); err != nil {
-		logger.Error(err, "Failed to create serviceAccount")
+	ClearReconcileError(&instance.Status.Conditions, instance.Generation)
+	instance.Status.ObservedGeneration = instance.Generation
+	if err = r.updateStatus(ctx, instance); err != nil {
+		logger.Error(err, "Failed to update higressController/status")
 		return ctrl.Result{}, err
 	}
 
-	if err = r.createRBAC(ctx, instance, logger); err != nil {
-		logger.Error(err, "Failed to create rbac")
-		return ctrl.Result{}, err
-	}
+	// objects that are not watched, such as the RBAC objects, are checked for drifts periodically
+	return ctrl.Result{RequeueAfter: DriftCheckInterval}, nil
+}
 
-	if err = r.createDeployment(ctx, instance, logger); err != nil {
-		logger.Error(err, "Failed to create deployment")
-		return ctrl.Result{}, err
+// reconcileFailed records the failed step in the status and returns err to requeue the request.
+func (r *HigressControllerReconciler) reconcileFailed(ctx context.Context, instance *operatorv1beta1.HigressController,
+	reason string, err error, logger logr.Logger) (ctrl.Result, error) {
+	r.Recorder.Event(instance, apiv1.EventTypeWarning, reason, err.Error())
+	SetReconcileError(&instance.Status.Conditions, instance.Generation, reason, err)
+	if statusErr := r.updateStatus(ctx, instance); statusErr != nil {
+		logger.Error(statusErr, "Failed to update higressController/status")
 	}
+	return ctrl.Result{}, err
+}
 
-	if err = r.createService(ctx, instance, logger); err != nil {
-		logger.Error(err, "Failed to create service")
-		return ctrl.Result{}, err
-	}
+// updateStatus refreshes the conditions derived from the controller Deployment and the field
+// conflicts of the reconcile, and writes the status.
+func (r *HigressControllerReconciler) updateStatus(ctx context.Context, instance *operatorv1beta1.HigressController) error {
+	SetFieldConflicts(&instance.Status.Conditions, instance.Generation, FieldConflicts(ctx))
 
-	if !instance.Status.Deployed {
-		instance.Status.Deployed = true
-		if err = r.Status().Update(ctx, instance); err != nil {
-			logger.Error(err, "Failed to update higressController/status")
-			return ctrl.Result{}, err
+	deploy := &appsv1.Deployment{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(instance), deploy); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
 		}
+		deploy = nil
 	}
 
-	return ctrl.Result{}, nil
+	SetDeploymentConditions(&instance.Status.Conditions, instance.Generation, deploy)
+	return r.Status().Update(ctx, instance)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *HigressControllerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// only the policy version served by the cluster can be watched, the reconciles apply the same one
+	var err error
+	if r.pdbVersion, err = GetPDBVersion(mgr.GetConfig()); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&operatorv1alpha1.HigressController{}).
+		For(&operatorv1beta1.HigressController{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&apiv1.Service{}).
 		Owns(&apiv1.ServiceAccount{}).
+		Owns(NewPDB(r.pdbVersion)).
+		Watches(&apiv1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.controllersOfGatewayConfig)).
 		Complete(r)
 }
 
-func (r *HigressControllerReconciler) createServiceAccount(ctx context.Context, instance *operatorv1alpha1.HigressController, logger logr.Logger) error {
-	if !instance.Spec.ServiceAccount.Enable {
+// controllersOfGatewayConfig returns the requests of the controllers that mount the gateway config obj.
+func (r *HigressControllerReconciler) controllersOfGatewayConfig(ctx context.Context, obj client.Object) []reconcile.Request {
+	controllers := &operatorv1beta1.HigressControllerList{}
+	if err := r.List(ctx, controllers, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list higressControllers")
 		return nil
 	}
 
-	var (
-		sa  = &apiv1.ServiceAccount{}
-		err error
-	)
-
-	sa = initServiceAccount(sa, instance)
-	if err = ctrl.SetControllerReference(instance, sa, r.Scheme); err != nil {
-		return err
+	var requests []reconcile.Request
+	for _, controller := range controllers.Items {
+		if GatewayConfigName(controller.Spec.Controller.GatewayName) == obj.GetName() ||
+			obj.GetLabels()[LabelGatewayConfigOf] == controller.Name {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&controller)})
+		}
 	}
+	return requests
+}
 
-	exist, err := CreateIfNotExits(ctx, r.Client, sa)
-	if err != nil {
-		logger.Error(err, fmt.Sprintf("Failed to create serviceAccount for HigressController(%v)", instance.Name))
-		return err
+func (r *HigressControllerReconciler) createServiceAccount(ctx context.Context, instance *operatorv1beta1.HigressController, logger logr.Logger) error {
+	if !instance.Spec.ServiceAccount.Enable {
+		return nil
 	}
 
-	if !exist {
-		logger.Info(fmt.Sprintf("Create servieAccount for HigressController(%v)", instance.Name))
+	sa := initServiceAccount(&apiv1.ServiceAccount{}, instance)
+	if err := ctrl.SetControllerReference(instance, sa, r.Scheme); err != nil {
+		return err
 	}
 
-	return nil
+	return Apply(ctx, r.Client, "ServiceAccount", sa, logger)
 }
 
-func (r *HigressControllerReconciler) createRBAC(ctx context.Context, instance *operatorv1alpha1.HigressController, log logr.Logger) error {
+func (r *HigressControllerReconciler) createRBAC(ctx context.Context, instance *operatorv1beta1.HigressController, log logr.Logger) error {
 	if instance.Spec.RBAC == nil {
-		instance.Spec.RBAC = &operatorv1alpha1.RBAC{Enable: true}
+		instance.Spec.RBAC = &operatorv1beta1.RBAC{Enable: true}
 	}
 	if instance.Spec.ServiceAccount == nil {
-		instance.Spec.ServiceAccount = &operatorv1alpha1.ServiceAccount{Enable: true}
+		instance.Spec.ServiceAccount = &operatorv1beta1.ServiceAccount{Enable: true}
 	}
 	if !instance.Spec.RBAC.Enable || !instance.Spec.ServiceAccount.Enable {
 		return nil
@@ -258,118 +335,272 @@ func (r *HigressControllerReconciler) createRBAC(ctx context.Context, instance *
 	)
 
 	initClusterRole(cr, instance)
-	if err := CreateOrUpdate(ctx, r.Client, "ClusterRole", cr, muteClusterRole(cr, instance), log); err != nil {
+	if err := Apply(ctx, r.Client, "ClusterRole", cr, log); err != nil {
 		return err
 	}
 
 	initClusterRoleBinding(crb, instance)
-	if err := CreateOrUpdate(ctx, r.Client, "ClusterRoleBinding", crb, muteClusterRoleBinding(crb, instance), log); err != nil {
+	if err := Apply(ctx, r.Client, "ClusterRoleBinding", crb, log); err != nil {
 		return err
 	}
 
 	initRole(role, instance)
-	if err := CreateOrUpdate(ctx, r.Client, "role", role, muteRole(role, instance), log); err != nil {
+	if err := Apply(ctx, r.Client, "role", role, log); err != nil {
 		return err
 	}
 
 	initRoleBinding(rb, instance)
-	if err := CreateOrUpdate(ctx, r.Client, "roleBinding", rb, muteRoleBinding(rb, instance), log); err != nil {
+	if err := Apply(ctx, r.Client, "roleBinding", rb, log); err != nil {
 		return err
 	}
 
-	return nil
+	// the instance is bound to its own ClusterRole now, so it is removed from the shared binding of
+	// the older releases, which is deleted with the shared ClusterRole once nothing is bound anymore
+	if getServiceAccount(instance) == crb.Name {
+		return nil
+	}
+	return DeleteClusterRBAC(ctx, r.Client, getServiceAccount(instance), legacyClusterRole, instance.Namespace, log)
 }
 
-func (r *HigressControllerReconciler) createDeployment(ctx context.Context, instance *operatorv1alpha1.HigressController, logger logr.Logger) error {
-	deploy := initDeployment(&appsv1.Deployment{}, instance)
+func (r *HigressControllerReconciler) createDeployment(ctx context.Context, instance *operatorv1beta1.HigressController, logger logr.Logger) error {
+	gatewayConfig := GatewayConfigName(instance.Spec.Controller.GatewayName)
+	// the pilot mounts the mesh config written by the gateway, so it is only created once that exists.
+	// An existing Deployment keeps being applied with the config it mounts, so that it isn't pruned.
+	if !instance.Spec.EnableHigressIstio {
+		name, err := r.findGatewayConfig(ctx, instance)
+		if err != nil {
+			return err
+		}
+		SetGatewayConfigAvailable(&instance.Status.Conditions, instance.Generation, gatewayConfig, name != "")
+		if name != "" {
+			gatewayConfig = name
+		} else {
+			live := &appsv1.Deployment{}
+			err = r.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}, live)
+			if client.IgnoreNotFound(err) != nil {
+				return err
+			}
+			if err != nil {
+				logger.Info(fmt.Sprintf("waiting for the gateway config %s/%s before deploying the controller", instance.Namespace, gatewayConfig))
+				return nil
+			}
+			if name = mountedGatewayConfig(live); name != "" {
+				gatewayConfig = name
+			}
+		}
+	} else {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, operatorv1beta1.ConditionGatewayConfigAvailable)
+	}
+
+	deploy := initDeployment(&appsv1.Deployment{}, instance, gatewayConfig)
 	if err := ctrl.SetControllerReference(instance, deploy, r.Scheme); err != nil {
 		return err
 	}
 
-	return CreateOrUpdate(ctx, r.Client, "Deployment", deploy, muteDeployment(deploy, instance), logger)
+	return Apply(ctx, r.Client, "Deployment", deploy, logger)
 }
 
-func (r *HigressControllerReconciler) createService(ctx context.Context, instance *operatorv1alpha1.HigressController, logger logr.Logger) error {
+// findGatewayConfig returns the name of the gateway config that the controller mounts, the one of
+// the gateway of its gatewayName in its namespace or else a copy written by a gateway of another
+// namespace bound to it. An empty name is returned if there is none yet.
+func (r *HigressControllerReconciler) findGatewayConfig(ctx context.Context, instance *operatorv1beta1.HigressController) (string, error) {
+	name := GatewayConfigName(instance.Spec.Controller.GatewayName)
+	err := r.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: name}, &apiv1.ConfigMap{})
+	if err == nil {
+		return name, nil
+	} else if !errors.IsNotFound(err) {
+		return "", err
+	}
+
+	copies := &apiv1.ConfigMapList{}
+	if err = r.List(ctx, copies, client.InNamespace(instance.Namespace),
+		client.MatchingLabels{LabelGatewayConfigOf: instance.Name}); err != nil {
+		return "", err
+	}
+	name = ""
+	for _, cm := range copies.Items {
+		if name == "" || cm.Name < name {
+			name = cm.Name
+		}
+	}
+	return name, nil
+}
+
+func (r *HigressControllerReconciler) createService(ctx context.Context, instance *operatorv1beta1.HigressController, logger logr.Logger) error {
 	svc := initService(&apiv1.Service{}, instance)
 	if err := ctrl.SetControllerReference(instance, svc, r.Scheme); err != nil {
 		return err
 	}
 
-	return CreateOrUpdate(ctx, r.Client, "Service", svc, muteService(svc, instance), logger)
+	return Apply(ctx, r.Client, "Service", svc, logger)
 }
 
-func (r *HigressControllerReconciler) finalizeHigressController(instance *operatorv1alpha1.HigressController, logger logr.Logger) error {
-	if !instance.Spec.RBAC.Enable || !instance.Spec.ServiceAccount.Enable {
+// createPDB applies the PodDisruptionBudget of the controller pods in the policy version served by the
+// cluster, it is pruned once it is disabled.
+func (r *HigressControllerReconciler) createPDB(ctx context.Context, instance *operatorv1beta1.HigressController, logger logr.Logger) error {
+	if !PDBEnabled(&instance.Spec.CRDCommonFields) {
 		return nil
 	}
 
-	var (
-		ctx = context.TODO()
-		crb = &rbacv1.ClusterRoleBinding{}
-	)
+	pdb := InitPDB(r.pdbVersion, metav1.ObjectMeta{
+		Name:      instance.Name,
+		Namespace: instance.Namespace,
+		Labels:    instance.Labels,
+	}, &instance.Spec.CRDCommonFields)
+	if err := ctrl.SetControllerReference(instance, pdb, r.Scheme); err != nil {
+		return err
+	}
+
+	return Apply(ctx, r.Client, "PodDisruptionBudget", pdb, logger)
+}
+
+// createClasses applies the IngressClass and, if the cluster serves the Gateway API, the
+// GatewayClass named by the ingress class of instance. The ones of a former name are pruned.
+func (r *HigressControllerReconciler) createClasses(ctx context.Context, instance *operatorv1beta1.HigressController, logger logr.Logger) error {
+	if instance.Spec.Controller.IngressClass == "" {
+		return nil
+	}
 
-	name := getServiceAccount(instance)
-	nn := types.NamespacedName{Name: name, Namespace: apiv1.NamespaceAll}
-	if err := r.Get(ctx, nn, crb); err != nil {
+	if err := Apply(ctx, r.Client, "IngressClass", initIngressClass(&networkingv1.IngressClass{}, instance), logger); err != nil {
 		return err
 	}
 
-	var subjects []rbacv1.Subject
-	for _, subject := range crb.Subjects {
-		if subject.Name != name || subject.Namespace != instance.Namespace {
-			subjects = append(subjects, subject)
+	mapping, err := r.RESTMapper().RESTMapping(schema.GroupKind{Group: "gateway.networking.k8s.io", Kind: "GatewayClass"})
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			logger.Info("the Gateway API is not installed, skip the GatewayClass")
+			return nil
 		}
+		return err
 	}
-	crb.Subjects = subjects
-	return r.Update(ctx, crb)
+
+	return Apply(ctx, r.Client, "GatewayClass", initGatewayClass(mapping.GroupVersionKind, instance), logger)
 }
 
-func (r *HigressControllerReconciler) createCRDs(ctx context.Context, logger logr.Logger) error {
-	apixClient, err := apixv1client.NewForConfig(r.Config)
+// finalizeHigressController deletes the objects of the instance, including its ClusterRole and
+// ClusterRoleBinding, or keeps them running without the instance if the deletion policy is Retain.
+// Objects that are already gone are skipped, so that the instance can always be deleted.
+func (r *HigressControllerReconciler) finalizeHigressController(ctx context.Context, instance *operatorv1beta1.HigressController, logger logr.Logger) error {
+	if instance.Spec.DeletionPolicy == operatorv1beta1.DeletionPolicyRetain {
+		return Orphan(ctx, r.Client, instance, instance.Status.Inventory, logger)
+	}
+
+	if err := Prune(ctx, r.Client, instance.Status.Inventory, logger); err != nil {
+		return err
+	}
+
+	if err := DeleteClusterRBAC(ctx, r.Client, getClusterRoleName(instance), getClusterRoleName(instance), instance.Namespace, logger); err != nil {
+		return err
+	}
+
+	// instances that were not reconciled since the upgrade may still be subjects of the shared binding
+	return DeleteClusterRBAC(ctx, r.Client, getServiceAccount(instance), legacyClusterRole, instance.Namespace, logger)
+}
+
+// createCRDs installs the embedded CRDs of the Higress release of the controller according to the
+// CRD install policy, and waits for them to be established. CRDs of newer releases are kept.
+func (r *HigressControllerReconciler) createCRDs(ctx context.Context, instance *operatorv1beta1.HigressController, logger logr.Logger) error {
+	if instance.Spec.CRDInstallPolicy == operatorv1beta1.CRDInstallPolicySkip {
+		return nil
+	}
+
+	// the CRDs of the older releases are left to the tools that installed them
+	desired, err := getCRDs(instance)
+	if goerrors.Is(err, crds.ErrReleaseNotEmbedded) {
+		if !meta.IsStatusConditionTrue(instance.Status.Conditions, operatorv1beta1.ConditionCRDsSkipped) {
+			r.Recorder.Eventf(instance, apiv1.EventTypeWarning, ReasonCRDsSkipped, "Skipped the CRD install: %v", err)
+		}
+		logger.Info(fmt.Sprintf("skip the CRD install: %v", err))
+		SetCRDsSkipped(&instance.Status.Conditions, instance.Generation, err.Error())
+		return nil
+	} else if err != nil {
+		return err
+	}
+	SetCRDsSkipped(&instance.Status.Conditions, instance.Generation, "")
+
+	return r.installCRDs(ctx, instance, desired, instance.Spec.CRDInstallPolicy == operatorv1beta1.CRDInstallPolicyUpgradeOnly, false, logger)
+}
+
+// createGatewayAPI installs the embedded Gateway API CRDs of the configured release and channel.
+// The CRDs installed by other tools are left alone, and disabling the Gateway API keeps the CRDs,
+// as they may still be used by the resources of the cluster.
+func (r *HigressControllerReconciler) createGatewayAPI(ctx context.Context, instance *operatorv1beta1.HigressController, logger logr.Logger) error {
+	gatewayAPI := instance.Spec.GatewayAPI
+	if gatewayAPI == nil || !gatewayAPI.Enable || instance.Spec.CRDInstallPolicy == operatorv1beta1.CRDInstallPolicySkip {
+		return nil
+	}
+
+	desired, err := crds.GetGatewayAPI(gatewayAPI.Version, string(gatewayAPI.Channel))
 	if err != nil {
 		return err
 	}
+	for _, crd := range desired {
+		if crd.Labels == nil {
+			crd.Labels = map[string]string{}
+		}
+		crd.Labels[LabelManagedBy] = ManagedByOperator
+	}
+
+	return r.installCRDs(ctx, instance, desired, instance.Spec.CRDInstallPolicy == operatorv1beta1.CRDInstallPolicyUpgradeOnly, true, logger)
+}
 
-	crds, err := getCRDs()
+// installCRDs creates the missing CRDs of desired unless upgradeOnly is set, upgrades the older
+// ones and waits for them to be established. If requireOwned is set, the existing CRDs that were
+// not created by the operator are skipped.
+func (r *HigressControllerReconciler) installCRDs(ctx context.Context, instance *operatorv1beta1.HigressController,
+	desired []*apixv1.CustomResourceDefinition, upgradeOnly, requireOwned bool, logger logr.Logger) error {
+	apixClient, err := apixv1client.NewForConfig(r.Config)
 	if err != nil {
 		return err
 	}
 
+	var names []string
 	cli := apixClient.CustomResourceDefinitions()
-	for _, crd := range crds {
-		if existing, err := cli.Get(ctx, crd.Name, metav1.GetOptions{TypeMeta: crd.TypeMeta}); err != nil {
-			if !errors.IsNotFound(err) {
-				logger.Error(err, fmt.Sprintf("failed to get CRD %v", crd.Name))
-				return err
+	for _, crd := range desired {
+		existing, err := cli.Get(ctx, crd.Name, metav1.GetOptions{TypeMeta: crd.TypeMeta})
+		switch {
+		case errors.IsNotFound(err):
+			if upgradeOnly {
+				logger.Info(fmt.Sprintf("CRD %v doesn't exist, skip it by the UpgradeOnly policy", crd.Name))
+				continue
 			}
 			if _, err = cli.Create(ctx, crd, metav1.CreateOptions{TypeMeta: crd.TypeMeta}); err != nil {
 				logger.Error(err, fmt.Sprintf("failed to create CRD %v", crd.Name))
 				return err
 			}
-		} else if !equality.Semantic.DeepEqual(existing.Spec, crd.Spec) {
-			// todo(lql): We should check if it has changed before updating it
-			existing.Spec = crd.Spec
-			if _, err = cli.Update(ctx, existing, metav1.UpdateOptions{TypeMeta: crd.TypeMeta}); err != nil {
+			r.Recorder.Eventf(instance, apiv1.EventTypeNormal, ReasonCRDInstalled, "Installed CRD %s", crd.Name)
+		case err != nil:
+			logger.Error(err, fmt.Sprintf("failed to get CRD %v", crd.Name))
+			return err
+		case requireOwned && existing.Labels[LabelManagedBy] != ManagedByOperator:
+			logger.Info(fmt.Sprintf("CRD %v is managed by %q, skip it", crd.Name, existing.Labels[LabelManagedBy]))
+			r.Recorder.Eventf(instance, apiv1.EventTypeWarning, ReasonCRDNotOwned,
+				"CRD %s was not installed by the operator and is left alone", crd.Name)
+		case isNewer(existing, crd):
+			logger.Info(fmt.Sprintf("CRD %v is newer than the embedded one, skip the downgrade", crd.Name))
+		default:
+			updated, err := applyCRD(ctx, cli, crd)
+			if err != nil {
 				logger.Error(err, fmt.Sprintf("failed to update CRD %v", crd.Name))
 				return err
 			}
+			if updated.ResourceVersion != existing.ResourceVersion {
+				r.Recorder.Eventf(instance, apiv1.EventTypeNormal, ReasonCRDUpdated, "Updated CRD %s", crd.Name)
+			}
 		}
+		names = append(names, crd.Name)
 	}
-	return nil
+
+	return waitForCRDs(ctx, cli, names)
 }
 
-func (r *HigressControllerReconciler) setDefaultValues(instance *operatorv1alpha1.HigressController) {
-	if instance.Spec.RBAC == nil {
-		instance.Spec.RBAC = &operatorv1alpha1.RBAC{Enable: true}
-	}
-	// serviceAccount
-	if instance.Spec.ServiceAccount == nil {
-		instance.Spec.ServiceAccount = &operatorv1alpha1.ServiceAccount{Enable: true, Name: "higress-controller"}
-	}
-	// SelectorLabels
-	if len(instance.Spec.SelectorLabels) == 0 {
-		instance.Spec.SelectorLabels = map[string]string{
-			"app": "higress-controller",
-		}
+// prune deletes the objects of the inventory that were not applied in this reconcile and
+// records the new inventory.
+func (r *HigressControllerReconciler) prune(ctx context.Context, instance *operatorv1beta1.HigressController, logger logr.Logger) error {
+	if err := Prune(ctx, r.Client, instance.Status.Inventory, logger); err != nil {
+		return err
 	}
+
+	instance.Status.Inventory = Inventory(ctx)
+	return nil
 }
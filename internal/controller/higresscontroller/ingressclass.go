@@ -0,0 +1,50 @@
+package higresscontroller
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+const (
+	// IngressClassController is the controller of the IngressClasses served by Higress.
+	IngressClassController = "higress.io/higress-controller"
+	// GatewayClassController is the controller of the GatewayClasses served by Higress.
+	GatewayClassController = "higress.io/gateway-controller"
+)
+
+func initIngressClass(ic *networkingv1.IngressClass, instance *operatorv1beta1.HigressController) *networkingv1.IngressClass {
+	*ic = networkingv1.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   instance.Spec.Controller.IngressClass,
+			Labels: instance.Labels,
+		},
+		Spec: networkingv1.IngressClassSpec{
+			Controller: IngressClassController,
+		},
+	}
+
+	if instance.Spec.Controller.IsDefaultClass {
+		ic.Annotations = map[string]string{networkingv1.AnnotationIsDefaultIngressClass: "true"}
+	}
+
+	return ic
+}
+
+// initGatewayClass returns the GatewayClass of instance in the version gvk served by the cluster.
+// The Gateway API types are not part of the scheme, so the object is unstructured.
+func initGatewayClass(gvk schema.GroupVersionKind, instance *operatorv1beta1.HigressController) *unstructured.Unstructured {
+	gc := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"controllerName": GatewayClassController,
+		},
+	}}
+	gc.SetGroupVersionKind(gvk)
+	gc.SetName(instance.Spec.Controller.IngressClass)
+	gc.SetLabels(instance.Labels)
+
+	return gc
+}
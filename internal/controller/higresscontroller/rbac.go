@@ -1,18 +1,18 @@
 package higresscontroller
 
 import (
+	"fmt"
 	"reflect"
 
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	operatorv1alpha1 "github.com/alibaba/higress/higress-operator/api/v1alpha1"
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
 )
 
 const (
-	role        = "higress-controller"
-	clusterRole = "higress-controller"
+	// legacyClusterRole is the ClusterRole that was shared by all controllers before each got its own.
+	legacyClusterRole = "higress-controller"
 )
 
 func defaultRules() []rbacv1.PolicyRule {
@@ -139,35 +139,28 @@ func defaultRules() []rbacv1.PolicyRule {
 	return rules
 }
 
-func initClusterRole(cr *rbacv1.ClusterRole, instance *operatorv1alpha1.HigressController) *rbacv1.ClusterRole {
+func initClusterRole(cr *rbacv1.ClusterRole, instance *operatorv1beta1.HigressController) *rbacv1.ClusterRole {
 	if cr == nil {
 		return nil
 	}
 
 	*cr = rbacv1.ClusterRole{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: clusterRole,
+			Name: getClusterRoleName(instance),
 		},
 		Rules: defaultRules(),
 	}
 	return cr
 }
 
-func muteClusterRole(cr *rbacv1.ClusterRole, instance *operatorv1alpha1.HigressController) controllerutil.MutateFn {
-	return func() error {
-		cr.Rules = defaultRules()
-		return nil
-	}
-}
-
-func initClusterRoleBinding(crb *rbacv1.ClusterRoleBinding, instance *operatorv1alpha1.HigressController) *rbacv1.ClusterRoleBinding {
+func initClusterRoleBinding(crb *rbacv1.ClusterRoleBinding, instance *operatorv1beta1.HigressController) *rbacv1.ClusterRoleBinding {
 	if crb == nil {
 		return nil
 	}
 
 	*crb = rbacv1.ClusterRoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: getServiceAccount(instance),
+			Name: getClusterRoleName(instance),
 		},
 	}
 
@@ -175,10 +168,10 @@ func initClusterRoleBinding(crb *rbacv1.ClusterRoleBinding, instance *operatorv1
 	return crb
 }
 
-func updateClusterRoleBinding(crb *rbacv1.ClusterRoleBinding, instance *operatorv1alpha1.HigressController) {
+func updateClusterRoleBinding(crb *rbacv1.ClusterRoleBinding, instance *operatorv1beta1.HigressController) {
 	crb.RoleRef = rbacv1.RoleRef{
 		Kind:     "ClusterRole",
-		Name:     clusterRole,
+		Name:     getClusterRoleName(instance),
 		APIGroup: "rbac.authorization.k8s.io",
 	}
 
@@ -197,17 +190,10 @@ func updateClusterRoleBinding(crb *rbacv1.ClusterRoleBinding, instance *operator
 	crb.Subjects = append(crb.Subjects, subject)
 }
 
-func muteClusterRoleBinding(crb *rbacv1.ClusterRoleBinding, instance *operatorv1alpha1.HigressController) controllerutil.MutateFn {
-	return func() error {
-		updateClusterRoleBinding(crb, instance)
-		return nil
-	}
-}
-
-func initRoleBinding(rb *rbacv1.RoleBinding, instance *operatorv1alpha1.HigressController) *rbacv1.RoleBinding {
+func initRoleBinding(rb *rbacv1.RoleBinding, instance *operatorv1beta1.HigressController) *rbacv1.RoleBinding {
 	*rb = rbacv1.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      getServiceAccount(instance),
+			Name:      getRoleName(instance),
 			Namespace: instance.Namespace,
 		},
 	}
@@ -216,10 +202,10 @@ func initRoleBinding(rb *rbacv1.RoleBinding, instance *operatorv1alpha1.HigressC
 	return rb
 }
 
-func updateRoleBinding(rb *rbacv1.RoleBinding, instance *operatorv1alpha1.HigressController) {
+func updateRoleBinding(rb *rbacv1.RoleBinding, instance *operatorv1beta1.HigressController) {
 	rb.RoleRef = rbacv1.RoleRef{
 		Kind:     "Role",
-		Name:     role,
+		Name:     getRoleName(instance),
 		APIGroup: "rbac.authorization.k8s.io",
 	}
 
@@ -238,17 +224,10 @@ func updateRoleBinding(rb *rbacv1.RoleBinding, instance *operatorv1alpha1.Higres
 	rb.Subjects = append(rb.Subjects, subject)
 }
 
-func muteRoleBinding(rb *rbacv1.RoleBinding, instance *operatorv1alpha1.HigressController) controllerutil.MutateFn {
-	return func() error {
-		updateRoleBinding(rb, instance)
-		return nil
-	}
-}
-
-func initRole(r *rbacv1.Role, instance *operatorv1alpha1.HigressController) *rbacv1.Role {
+func initRole(r *rbacv1.Role, instance *operatorv1beta1.HigressController) *rbacv1.Role {
 	*r = rbacv1.Role{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      role,
+			Name:      getRoleName(instance),
 			Namespace: instance.Namespace,
 		},
 		Rules: defaultRules(),
@@ -257,9 +236,14 @@ func initRole(r *rbacv1.Role, instance *operatorv1alpha1.HigressController) *rba
 	return r
 }
 
-func muteRole(role *rbacv1.Role, instance *operatorv1alpha1.HigressController) controllerutil.MutateFn {
-	return func() error {
-		role.Rules = defaultRules()
-		return nil
-	}
+// getRoleName returns the name of the Role and RoleBinding of instance, so that the controllers in
+// one namespace don't share them.
+func getRoleName(instance *operatorv1beta1.HigressController) string {
+	return instance.Name
+}
+
+// getClusterRoleName returns the name of the ClusterRole and ClusterRoleBinding of instance, which
+// include the namespace so that the controllers in different namespaces don't share them.
+func getClusterRoleName(instance *operatorv1beta1.HigressController) string {
+	return fmt.Sprintf("%s-%s", instance.Name, instance.Namespace)
 }
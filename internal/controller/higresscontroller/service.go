@@ -3,16 +3,17 @@ package higresscontroller
 import (
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	operatorv1alpha1 "github.com/alibaba/higress/higress-operator/api/v1alpha1"
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
 )
 
 const (
 	HigressControllerServiceName = "higress-controller"
+	// GatewaySelectorKey is the label that the controller selects the pods of its gateways by.
+	GatewaySelectorKey = "higress"
 )
 
-func initService(svc *apiv1.Service, instance *operatorv1alpha1.HigressController) *apiv1.Service {
+func initService(svc *apiv1.Service, instance *operatorv1beta1.HigressController) *apiv1.Service {
 	*svc = apiv1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      HigressControllerServiceName,
@@ -25,7 +26,7 @@ func initService(svc *apiv1.Service, instance *operatorv1alpha1.HigressControlle
 	return svc
 }
 
-func updateServiceSpec(svc *apiv1.Service, instance *operatorv1alpha1.HigressController) {
+func updateServiceSpec(svc *apiv1.Service, instance *operatorv1beta1.HigressController) {
 	svc.Spec.Selector = instance.Spec.SelectorLabels
 	svc.Spec.Type = apiv1.ServiceTypeClusterIP
 	if s := instance.Spec.Service; s != nil {
@@ -35,8 +36,16 @@ func updateServiceSpec(svc *apiv1.Service, instance *operatorv1alpha1.HigressCon
 		svc.Spec.Ports = s.Ports
 	}
 
+	// the API of the controller is read by the consoles
+	ports := []apiv1.ServicePort{
+		{
+			Name:     "http",
+			Protocol: apiv1.ProtocolTCP,
+			Port:     8888,
+		},
+	}
 	if !instance.Spec.EnableHigressIstio {
-		ports := []apiv1.ServicePort{
+		ports = append(ports, []apiv1.ServicePort{
 			{
 				Name:     "grpc-xds",
 				Protocol: apiv1.ProtocolTCP,
@@ -57,22 +66,19 @@ func updateServiceSpec(svc *apiv1.Service, instance *operatorv1alpha1.HigressCon
 				Protocol: apiv1.ProtocolTCP,
 				Port:     15014,
 			},
-		}
-		set := make(map[string]struct{})
-		for _, port := range svc.Spec.Ports {
-			set[port.Name] = struct{}{}
-		}
-		for _, port := range ports {
-			if _, ok := set[port.Name]; !ok {
-				svc.Spec.Ports = append(svc.Spec.Ports, port)
-			}
-		}
+		}...)
 	}
-}
 
-func muteService(svc *apiv1.Service, instance *operatorv1alpha1.HigressController) controllerutil.MutateFn {
-	return func() error {
-		updateServiceSpec(svc, instance)
-		return nil
+	names, numbers := make(map[string]struct{}), make(map[int32]struct{})
+	for _, port := range svc.Spec.Ports {
+		names[port.Name] = struct{}{}
+		numbers[port.Port] = struct{}{}
+	}
+	for _, port := range ports {
+		_, named := names[port.Name]
+		_, numbered := numbers[port.Port]
+		if !named && !numbered {
+			svc.Spec.Ports = append(svc.Spec.Ports, port)
+		}
 	}
 }
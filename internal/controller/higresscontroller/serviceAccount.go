@@ -4,10 +4,10 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	operatorv1alpha1 "github.com/alibaba/higress/higress-operator/api/v1alpha1"
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
 )
 
-func initServiceAccount(sa *apiv1.ServiceAccount, instance *operatorv1alpha1.HigressController) *apiv1.ServiceAccount {
+func initServiceAccount(sa *apiv1.ServiceAccount, instance *operatorv1beta1.HigressController) *apiv1.ServiceAccount {
 	*sa = apiv1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace:   instance.Namespace,
@@ -20,7 +20,7 @@ func initServiceAccount(sa *apiv1.ServiceAccount, instance *operatorv1alpha1.Hig
 	return sa
 }
 
-func getServiceAccount(instance *operatorv1alpha1.HigressController) string {
+func getServiceAccount(instance *operatorv1beta1.HigressController) string {
 	if instance.Spec.ServiceAccount.Name != "" {
 		return instance.Spec.ServiceAccount.Name
 	}
@@ -0,0 +1,49 @@
+package higresscontroller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
+	. "github.com/alibaba/higress/higress-operator/internal/controller"
+)
+
+// checkIngressClass fails if an older HigressController serves the ingress class of instance, as
+// two controllers would overwrite the status of each other's ingresses.
+func (r *HigressControllerReconciler) checkIngressClass(ctx context.Context, instance *operatorv1beta1.HigressController, logger logr.Logger) error {
+	controllers := &operatorv1beta1.HigressControllerList{}
+	if err := r.List(ctx, controllers); err != nil {
+		return err
+	}
+
+	var owner *operatorv1beta1.HigressController
+	for i := range controllers.Items {
+		other := &controllers.Items[i]
+		if other.UID == instance.UID || other.Spec.Controller.IngressClass != instance.Spec.Controller.IngressClass {
+			continue
+		}
+		if precedes(other, instance) && (owner == nil || precedes(other, owner)) {
+			owner = other
+		}
+	}
+
+	if owner == nil {
+		SetIngressClassConflict(&instance.Status.Conditions, instance.Generation, instance.Spec.Controller.IngressClass, "")
+		return nil
+	}
+
+	key := client.ObjectKeyFromObject(owner).String()
+	SetIngressClassConflict(&instance.Status.Conditions, instance.Generation, instance.Spec.Controller.IngressClass, key)
+	return fmt.Errorf("ingress class %q is already served by HigressController %s", instance.Spec.Controller.IngressClass, key)
+}
+
+// precedes orders the controllers by their creation, the namespaced names break the ties.
+func precedes(a, b *operatorv1beta1.HigressController) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return client.ObjectKeyFromObject(a).String() < client.ObjectKeyFromObject(b).String()
+}
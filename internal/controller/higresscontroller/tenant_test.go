@@ -0,0 +1,60 @@
+package higresscontroller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+func TestCheckIngressClass(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := v1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	created := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	controller := func(namespace, ingressClass string, age time.Duration) *v1beta1.HigressController {
+		instance := &v1beta1.HigressController{ObjectMeta: metav1.ObjectMeta{
+			Name: "higress-controller", Namespace: namespace, UID: types.UID("uid-" + namespace),
+			CreationTimestamp: metav1.NewTime(created.Add(-age)),
+		}}
+		instance.Spec.Controller.IngressClass = ingressClass
+		return instance
+	}
+
+	tests := []struct {
+		name      string
+		instance  *v1beta1.HigressController
+		wantOwner bool
+	}{
+		{name: "own ingress class", instance: controller("team-a", "team-a", 0)},
+		{name: "class of an older controller", instance: controller("team-b", "higress", 0), wantOwner: true},
+		{name: "class of a newer controller", instance: controller("team-b", "higress", 2*time.Hour)},
+		// the namespaced names order the controllers created at the same time
+		{name: "class of a controller created at the same time", instance: controller("team-b", "higress", time.Hour), wantOwner: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &HigressControllerReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				controller("higress-system", "higress", time.Hour), tt.instance,
+			).Build()}
+
+			err := r.checkIngressClass(context.Background(), tt.instance, logr.Discard())
+			if (err != nil) != tt.wantOwner {
+				t.Fatalf("checkIngressClass() error = %v, want a conflict %v", err, tt.wantOwner)
+			}
+			if got := meta.IsStatusConditionTrue(tt.instance.Status.Conditions, v1beta1.ConditionIngressClassConflict); got != tt.wantOwner {
+				t.Errorf("IngressClassConflict = %v, want %v", got, tt.wantOwner)
+			}
+		})
+	}
+}
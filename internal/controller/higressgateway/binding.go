@@ -0,0 +1,121 @@
+package higressgateway
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
+	"github.com/alibaba/higress/higress-operator/internal/controller/higresscontroller"
+)
+
+// controllerBinding holds the settings that a gateway takes from the HigressController it is bound
+// to. A gateway without a controllerRef is bound to the controller in its own namespace and keeps
+// its own trust settings.
+type controllerBinding struct {
+	// Controller is the name of the controller of the controllerRef, empty without one.
+	Controller string
+	// Namespace is the namespace of the controller, which is the root namespace of the mesh config.
+	Namespace        string
+	DiscoveryAddress string
+	// GatewaySelector is the value of the higress label that the controller selects its gateways
+	// by, empty if the gateway is only selected by its selector labels.
+	GatewaySelector string
+
+	// the trust settings of the gateway, the ones of the controller of the controllerRef
+	JwtPolicy          string
+	EnableHigressIstio bool
+	IstioNamespace     string
+}
+
+type controllerBindingKey struct{}
+
+func withControllerBinding(ctx context.Context, binding *controllerBinding) context.Context {
+	return context.WithValue(ctx, controllerBindingKey{}, binding)
+}
+
+func getControllerBinding(ctx context.Context, instance *operatorv1beta1.HigressGateway) *controllerBinding {
+	if binding, ok := ctx.Value(controllerBindingKey{}).(*controllerBinding); ok {
+		return binding
+	}
+	return defaultControllerBinding(instance)
+}
+
+func defaultControllerBinding(instance *operatorv1beta1.HigressGateway) *controllerBinding {
+	service := higresscontroller.HigressControllerServiceName
+	if instance.Spec.EnableHigressIstio {
+		service = "istiod"
+	}
+
+	return &controllerBinding{
+		Namespace:          instance.Namespace,
+		DiscoveryAddress:   fmt.Sprintf("%s.%s.svc:15012", service, instance.Namespace),
+		JwtPolicy:          instance.Spec.JwtPolicy,
+		EnableHigressIstio: instance.Spec.EnableHigressIstio,
+		IstioNamespace:     instance.Spec.IstioNamespace,
+	}
+}
+
+// resolveControllerBinding looks up the HigressController of the controllerRef of instance. The
+// binding has the trust settings of the controller instead of the ones of instance, as the gateway
+// can't connect to a controller that issues tokens or certificates in another way.
+func resolveControllerBinding(ctx context.Context, cli client.Client, instance *operatorv1beta1.HigressGateway) (*controllerBinding, error) {
+	ref := instance.Spec.ControllerRef
+	if ref == nil {
+		return defaultControllerBinding(instance), nil
+	}
+
+	key := client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}
+	if key.Namespace == "" {
+		key.Namespace = instance.Namespace
+	}
+	ctrl := &operatorv1beta1.HigressController{}
+	if err := cli.Get(ctx, key, ctrl); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("HigressController %s of the controllerRef is not found", key)
+		}
+		return nil, err
+	}
+
+	binding := &controllerBinding{
+		Controller:         ctrl.Name,
+		Namespace:          ctrl.Namespace,
+		DiscoveryAddress:   fmt.Sprintf("%s.%s.svc:15012", higresscontroller.HigressControllerServiceName, ctrl.Namespace),
+		GatewaySelector:    higresscontroller.GatewaySelectorValue(ctrl),
+		JwtPolicy:          ctrl.Spec.JwtPolicy,
+		EnableHigressIstio: ctrl.Spec.EnableHigressIstio,
+		IstioNamespace:     ctrl.Spec.IstioNamespace,
+	}
+	if ctrl.Spec.EnableHigressIstio {
+		istioNamespace := ctrl.Spec.IstioNamespace
+		if istioNamespace == "" {
+			istioNamespace = ctrl.Namespace
+		}
+		binding.DiscoveryAddress = fmt.Sprintf("istiod.%s.svc:15012", istioNamespace)
+	}
+
+	// the label is added to the pod template, which has to keep matching the immutable selector
+	if value, ok := instance.Spec.SelectorLabels[higresscontroller.GatewaySelectorKey]; ok && value != binding.GatewaySelector {
+		return nil, fmt.Errorf("the selector label %s=%s doesn't match the gateways selected by HigressController %s (%s=%s)",
+			higresscontroller.GatewaySelectorKey, value, key, higresscontroller.GatewaySelectorKey, binding.GatewaySelector)
+	}
+
+	return binding, nil
+}
+
+// podLabels returns the labels of the gateway pods, the selector labels and the label that the
+// bound controller selects them by.
+func podLabels(instance *operatorv1beta1.HigressGateway, binding *controllerBinding) map[string]string {
+	if binding.GatewaySelector == "" {
+		return instance.Spec.SelectorLabels
+	}
+
+	labels := make(map[string]string, len(instance.Spec.SelectorLabels)+1)
+	for k, v := range instance.Spec.SelectorLabels {
+		labels[k] = v
+	}
+	labels[higresscontroller.GatewaySelectorKey] = binding.GatewaySelector
+	return labels
+}
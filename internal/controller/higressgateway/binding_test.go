@@ -0,0 +1,48 @@
+package higressgateway
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+func TestResolveControllerBinding(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := v1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	ctrl := &v1beta1.HigressController{
+		ObjectMeta: metav1.ObjectMeta{Name: "higress-controller", Namespace: "higress-system"},
+	}
+	ctrl.Spec.EnableHigressIstio = true
+	ctrl.Spec.IstioNamespace = "istio-system"
+	ctrl.Spec.JwtPolicy = "first-party-jwt"
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ctrl).Build()
+
+	instance := newGateway("edge", LatestDefaultsVersion)
+	instance.Spec.JwtPolicy = "third-party-jwt"
+	instance.Spec.ControllerRef = &v1beta1.ControllerReference{Name: "higress-controller", Namespace: "higress-system"}
+
+	binding, err := resolveControllerBinding(context.Background(), cli, instance)
+	if err != nil {
+		t.Fatalf("resolveControllerBinding() error = %v", err)
+	}
+	if binding.Controller != "higress-controller" || binding.Namespace != "higress-system" {
+		t.Errorf("binding = %+v", binding)
+	}
+	if binding.DiscoveryAddress != "istiod.istio-system.svc:15012" {
+		t.Errorf("discoveryAddress = %q", binding.DiscoveryAddress)
+	}
+	if binding.JwtPolicy != "first-party-jwt" || !binding.EnableHigressIstio || binding.IstioNamespace != "istio-system" {
+		t.Errorf("trust settings = %+v, want the ones of the controller", binding)
+	}
+	// the spec of the gateway is left as it is
+	if instance.Spec.JwtPolicy != "third-party-jwt" || instance.Spec.EnableHigressIstio {
+		t.Errorf("spec = %+v, want it unchanged", instance.Spec)
+	}
+}
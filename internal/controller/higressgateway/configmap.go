@@ -6,30 +6,27 @@ import (
 	"gopkg.in/yaml.v2"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	operatorv1alpha1 "github.com/alibaba/higress/higress-operator/api/v1alpha1"
-	"github.com/alibaba/higress/higress-operator/internal/controller"
-	"github.com/alibaba/higress/higress-operator/internal/controller/higresscontroller"
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
 )
 
-func initGatewayConfigMap(cm *apiv1.ConfigMap, instance *operatorv1alpha1.HigressGateway) (*apiv1.ConfigMap, error) {
+func initGatewayConfigMap(cm *apiv1.ConfigMap, instance *operatorv1beta1.HigressGateway, binding *controllerBinding) (*apiv1.ConfigMap, error) {
 	*cm = apiv1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      controller.HigressGatewayConfig,
+			Name:      getConfigMapName(instance),
 			Namespace: instance.Namespace,
 			Labels:    instance.Labels,
 		},
 	}
 
-	if _, err := updateGatewayConfigMapSpec(cm, instance); err != nil {
+	if _, err := updateGatewayConfigMapSpec(cm, instance, binding); err != nil {
 		return nil, err
 	}
 
 	return cm, nil
 }
 
-func updateGatewayConfigMapSpec(cm *apiv1.ConfigMap, instance *operatorv1alpha1.HigressGateway) (*apiv1.ConfigMap, error) {
+func updateGatewayConfigMapSpec(cm *apiv1.ConfigMap, instance *operatorv1beta1.HigressGateway, binding *controllerBinding) (*apiv1.ConfigMap, error) {
 	var (
 		data              = map[string]string{}
 		err               error
@@ -48,30 +45,24 @@ func updateGatewayConfigMapSpec(cm *apiv1.ConfigMap, instance *operatorv1alpha1.
 
 	// rootNamespace
 	meshConfig := instance.Spec.MeshConfig
-	if instance.Spec.EnableHigressIstio {
+	if binding.EnableHigressIstio {
 		if meshConfig.RootNamespace == "" {
-			meshConfig.RootNamespace = instance.Spec.IstioNamespace
+			meshConfig.RootNamespace = binding.IstioNamespace
 		}
 	} else {
-		meshConfig.RootNamespace = instance.Namespace
+		meshConfig.RootNamespace = binding.Namespace
 	}
 
 	// configSources
 	if instance.Spec.EnableIstioAPI {
-		meshConfig.ConfigSources = append(meshConfig.ConfigSources, operatorv1alpha1.ConfigSource{
+		meshConfig.ConfigSources = append(meshConfig.ConfigSources, operatorv1beta1.ConfigSource{
 			Address: "k8s://",
 		})
 	}
 
 	// defaultConfig.tracing
 	// defaultConfig.discoveryAddress
-	if instance.Spec.EnableHigressIstio {
-		instance.Spec.MeshConfig.DefaultConfig.DiscoveryAddress =
-			fmt.Sprintf("%s.%s.svc:15012", "istiod", instance.Namespace)
-	} else {
-		instance.Spec.MeshConfig.DefaultConfig.DiscoveryAddress =
-			fmt.Sprintf("%s.%s.svc:15012", higresscontroller.HigressControllerServiceName, instance.Namespace)
-	}
+	meshConfig.DefaultConfig.DiscoveryAddress = binding.DiscoveryAddress
 	if meshConfigBytes, err = yaml.Marshal(meshConfig); err == nil {
 		data["mesh"] = string(meshConfigBytes)
 	}
@@ -84,13 +75,14 @@ func updateGatewayConfigMapSpec(cm *apiv1.ConfigMap, instance *operatorv1alpha1.
 	return cm, nil
 }
 
-func initSkywalkingConfigMap(cm *apiv1.ConfigMap, instance *operatorv1alpha1.HigressGateway) (*apiv1.ConfigMap, error) {
+func initSkywalkingConfigMap(cm *apiv1.ConfigMap, instance *operatorv1beta1.HigressGateway) (*apiv1.ConfigMap, error) {
 	*cm = apiv1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "skywalking-config",
+			Name:      getSkywalkingConfigMapName(instance),
 			Namespace: instance.Namespace,
 			Labels:    instance.Labels,
 		},
+		Data: map[string]string{},
 	}
 
 	if _, err := updateSkywalkingConfigMap(cm, instance); err != nil {
@@ -100,7 +92,7 @@ func initSkywalkingConfigMap(cm *apiv1.ConfigMap, instance *operatorv1alpha1.Hig
 	return cm, nil
 }
 
-func updateSkywalkingConfigMap(cm *apiv1.ConfigMap, instance *operatorv1alpha1.HigressGateway) (*apiv1.ConfigMap, error) {
+func updateSkywalkingConfigMap(cm *apiv1.ConfigMap, instance *operatorv1beta1.HigressGateway) (*apiv1.ConfigMap, error) {
 	skywalking := instance.Spec.Skywalking
 	if skywalking != nil && skywalking.Enable && len(skywalking.CustomBootStrap) == 0 {
 		return nil, fmt.Errorf("error empty skywalking custom bootstrap scripts")
@@ -110,13 +102,3 @@ func updateSkywalkingConfigMap(cm *apiv1.ConfigMap, instance *operatorv1alpha1.H
 
 	return cm, nil
 }
-
-func muteConfigMap(cm *apiv1.ConfigMap, instance *operatorv1alpha1.HigressGateway,
-	fn func(*apiv1.ConfigMap, *operatorv1alpha1.HigressGateway) (*apiv1.ConfigMap, error)) controllerutil.MutateFn {
-	return func() error {
-		if _, err := fn(cm, instance); err != nil {
-			return err
-		}
-		return nil
-	}
-}
@@ -0,0 +1,120 @@
+package higressgateway
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+	"github.com/alibaba/higress/higress-operator/internal/controller"
+)
+
+func newGateway(name string, version int) *v1beta1.HigressGateway {
+	instance := &v1beta1.HigressGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "tenant"},
+	}
+	if version > 0 {
+		controller.SetDefaultsVersion(instance, version)
+	}
+	return instance
+}
+
+func TestSetDefaultsInitialVersion(t *testing.T) {
+	// the instances without a recorded version keep the fixed names they were created with
+	instance := newGateway("edge", 0)
+	SetDefaults(instance)
+
+	want := map[string]string{"app": "higress-gateway", "higress": "higress-system-higress-gateway"}
+	if !reflect.DeepEqual(instance.Spec.SelectorLabels, want) {
+		t.Errorf("selectorLabels = %v, want %v", instance.Spec.SelectorLabels, want)
+	}
+	if got := instance.Spec.ServiceAccount.Name; got != "higress-gateway" {
+		t.Errorf("serviceAccount.name = %q, want higress-gateway", got)
+	}
+	for name, got := range map[string]string{
+		"service":   getServiceName(instance),
+		"container": getContainerName(instance),
+		"role":      getRoleName(instance),
+	} {
+		if got != "higress-gateway" {
+			t.Errorf("%s name = %q, want higress-gateway", name, got)
+		}
+	}
+	if got := getSkywalkingConfigMapName(instance); got != "higress-custom-bootstrap" {
+		t.Errorf("skywalking ConfigMap name = %q, want higress-custom-bootstrap", got)
+	}
+}
+
+func TestSetDefaultsLatestVersion(t *testing.T) {
+	instance := newGateway("edge", LatestDefaultsVersion)
+	SetDefaults(instance)
+
+	want := map[string]string{"app": "edge", "higress": "tenant-edge"}
+	if !reflect.DeepEqual(instance.Spec.SelectorLabels, want) {
+		t.Errorf("selectorLabels = %v, want %v", instance.Spec.SelectorLabels, want)
+	}
+	if got := instance.Spec.ServiceAccount.Name; got != "edge" {
+		t.Errorf("serviceAccount.name = %q, want edge", got)
+	}
+	if got := getServiceName(instance); got != "edge" {
+		t.Errorf("service name = %q, want edge", got)
+	}
+	if got := getSkywalkingConfigMapName(instance); got != "edge-custom-bootstrap" {
+		t.Errorf("skywalking ConfigMap name = %q, want edge-custom-bootstrap", got)
+	}
+	// the defaults of the initial version still fill the fields that the later ones leave unset
+	if instance.Spec.Replicas == nil || *instance.Spec.Replicas != 1 || instance.Spec.Service == nil {
+		t.Errorf("replicas = %v, service = %v", instance.Spec.Replicas, instance.Spec.Service)
+	}
+}
+
+func TestSetDefaultsKeepsSetFields(t *testing.T) {
+	instance := newGateway("edge", LatestDefaultsVersion)
+	instance.Spec.SelectorLabels = map[string]string{"app": "custom"}
+	instance.Spec.ServiceAccount = &v1beta1.ServiceAccount{Name: "custom"}
+	SetDefaults(instance)
+
+	if got := instance.Spec.SelectorLabels["app"]; got != "custom" || len(instance.Spec.SelectorLabels) != 1 {
+		t.Errorf("selectorLabels = %v, want the set ones", instance.Spec.SelectorLabels)
+	}
+	if got := instance.Spec.ServiceAccount.Name; got != "custom" {
+		t.Errorf("serviceAccount.name = %q, want custom", got)
+	}
+}
+
+func TestLegacyNamesConfigMap(t *testing.T) {
+	// a gateway of the initial version mounts the config map of the fixed name whatever its name is
+	for _, tt := range []struct {
+		version int
+		want    string
+	}{
+		{version: 1, want: "higress-gateway-config"},
+		{version: LatestDefaultsVersion, want: "edge-config"},
+	} {
+		instance := newGateway("edge", tt.version)
+		SetDefaults(instance)
+		binding := defaultControllerBinding(instance)
+
+		cm, err := initGatewayConfigMap(&apiv1.ConfigMap{}, instance, binding)
+		if err != nil {
+			t.Fatalf("version %d: initGatewayConfigMap() error = %v", tt.version, err)
+		}
+		if cm.Name != tt.want {
+			t.Errorf("version %d: ConfigMap name = %q, want %q", tt.version, cm.Name, tt.want)
+		}
+
+		deploy := initDeployment(&appsv1.Deployment{}, instance, binding)
+		mounted := ""
+		for _, volume := range deploy.Spec.Template.Spec.Volumes {
+			if volume.Name == "config" && volume.ConfigMap != nil {
+				mounted = volume.ConfigMap.Name
+			}
+		}
+		if mounted != tt.want {
+			t.Errorf("version %d: mounted config = %q, want %q", tt.version, mounted, tt.want)
+		}
+	}
+}
@@ -1,41 +1,40 @@
 package higressgateway
 
 import (
-	"fmt"
-
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	"github.com/alibaba/higress/higress-operator/api/v1alpha1"
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
 	"github.com/alibaba/higress/higress-operator/internal/controller"
 )
 
-const (
-	instanceName = "higress-gateway"
-)
-
-func initDeployment(deploy *appsv1.Deployment, instance *v1alpha1.HigressGateway) *appsv1.Deployment {
+func initDeployment(deploy *appsv1.Deployment, instance *v1beta1.HigressGateway, binding *controllerBinding) *appsv1.Deployment {
 	*deploy = appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        instance.Name,
 			Namespace:   instance.Namespace,
 			Labels:      instance.Labels,
-			Annotations: instance.Annotations,
+			Annotations: controller.ObjectAnnotations(instance),
 		},
 	}
 
-	updateDeploymentSpec(deploy, instance)
+	updateDeploymentSpec(deploy, instance, binding)
 
 	return deploy
 }
 
-func updateDeploymentSpec(deploy *appsv1.Deployment, instance *v1alpha1.HigressGateway) *appsv1.Deployment {
+func updateDeploymentSpec(deploy *appsv1.Deployment, instance *v1beta1.HigressGateway, binding *controllerBinding) *appsv1.Deployment {
+	// the replicas are left out of the apply with an autoscaler, so that the operator never owns them
+	replicas := instance.Spec.Replicas
+	if controller.AutoScalingEnabled(&instance.Spec.CRDCommonFields) {
+		replicas = nil
+	}
+
 	deploy.Spec = appsv1.DeploymentSpec{
-		Replicas: instance.Spec.Replicas,
+		Replicas: replicas,
 		Selector: &metav1.LabelSelector{
 			MatchLabels: instance.Spec.SelectorLabels,
 		},
@@ -49,33 +48,38 @@ func updateDeploymentSpec(deploy *appsv1.Deployment, instance *v1alpha1.HigressG
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      instance.Name,
 				Namespace: instance.Namespace,
-				Labels:    instance.Spec.SelectorLabels,
+				Labels:    podLabels(instance, binding),
 			},
 			Spec: apiv1.PodSpec{
-				ImagePullSecrets: instance.Spec.ImagePullSecrets,
-				SecurityContext:  genSecurityContextForPod(instance),
-				NodeSelector:     instance.Spec.NodeSelector,
-				Affinity:         instance.Spec.Affinity,
-				Tolerations:      instance.Spec.Toleration,
+				ImagePullSecrets:              instance.Spec.ImagePullSecrets,
+				SecurityContext:               genSecurityContextForPod(instance),
+				NodeSelector:                  instance.Spec.NodeSelector,
+				Affinity:                      instance.Spec.Affinity,
+				Tolerations:                   instance.Spec.Toleration,
+				TopologySpreadConstraints:     genTopologySpreadConstraints(instance),
+				PriorityClassName:             instance.Spec.PriorityClassName,
+				SchedulerName:                 instance.Spec.SchedulerName,
+				RuntimeClassName:              instance.Spec.RuntimeClassName,
+				TerminationGracePeriodSeconds: instance.Spec.TerminationGracePeriodSeconds,
 				Containers: []apiv1.Container{
 					{
-						Name:            instanceName,
+						Name:            getContainerName(instance),
 						Image:           genImage(instance),
 						Args:            genArgs(instance),
 						SecurityContext: genSecurityContextForContainer(instance),
-						Env:             genEnv(instance),
+						Env:             genEnv(instance, binding),
 						Ports:           genPorts(instance),
 						ReadinessProbe:  genProbe(instance),
-						VolumeMounts:    genVolumeMounts(instance),
+						VolumeMounts:    genVolumeMounts(instance, binding),
 					},
 				},
-				Volumes: genVolumes(instance),
+				Volumes: genVolumes(instance, binding),
 			},
 		},
 	}
 
 	// resource
-	if !instance.Spec.Local && instance.Spec.Resources != nil {
+	if instance.Spec.Resources != nil {
 		deploy.Spec.Template.Spec.Containers[0].Resources = *instance.Spec.Resources
 	}
 
@@ -93,14 +97,7 @@ func updateDeploymentSpec(deploy *appsv1.Deployment, instance *v1alpha1.HigressG
 	return deploy
 }
 
-func muteDeployment(deploy *appsv1.Deployment, instance *v1alpha1.HigressGateway) controllerutil.MutateFn {
-	return func() error {
-		updateDeploymentSpec(deploy, instance)
-		return nil
-	}
-}
-
-func genProbe(instance *v1alpha1.HigressGateway) *apiv1.Probe {
+func genProbe(instance *v1beta1.HigressGateway) *apiv1.Probe {
 	return &apiv1.Probe{
 		FailureThreshold: 30,
 		ProbeHandler: apiv1.ProbeHandler{
@@ -117,40 +114,22 @@ func genProbe(instance *v1alpha1.HigressGateway) *apiv1.Probe {
 	}
 }
 
-func genPorts(instance *v1alpha1.HigressGateway) []apiv1.ContainerPort {
+func genPorts(instance *v1beta1.HigressGateway) []apiv1.ContainerPort {
 	if len(instance.Spec.Ports) > 0 {
 		return instance.Spec.Ports
 	}
 
-	ports := []apiv1.ContainerPort{
+	// the gateways that bind the ports of their nodes set them with hostPort
+	return []apiv1.ContainerPort{
 		{
 			Name:          "http-envoy-prom",
 			Protocol:      "TCP",
 			ContainerPort: 15090,
 		},
 	}
-
-	if instance.Spec.Local {
-		ports = append(ports, []apiv1.ContainerPort{
-			{
-				Name:          "http",
-				Protocol:      "TCP",
-				ContainerPort: 80,
-				HostPort:      80,
-			},
-			{
-				Name:          "https",
-				Protocol:      "TCP",
-				ContainerPort: 443,
-				HostPort:      443,
-			},
-		}...)
-	}
-
-	return ports
 }
 
-func genEnv(instance *v1alpha1.HigressGateway) []apiv1.EnvVar {
+func genEnv(instance *v1beta1.HigressGateway, binding *controllerBinding) []apiv1.EnvVar {
 	envs := []apiv1.EnvVar{
 		{
 			Name: "NODE_NAME",
@@ -216,7 +195,7 @@ func genEnv(instance *v1alpha1.HigressGateway) []apiv1.EnvVar {
 		},
 		{
 			Name:  "JWT_POLICY",
-			Value: instance.Spec.JwtPolicy,
+			Value: binding.JwtPolicy,
 		},
 		{
 			Name:  "ISTIO_META_HTTP10",
@@ -228,7 +207,7 @@ func genEnv(instance *v1alpha1.HigressGateway) []apiv1.EnvVar {
 		},
 		{
 			Name:  "INSTANCE_NAME",
-			Value: instanceName,
+			Value: getContainerName(instance),
 		},
 	}
 
@@ -240,25 +219,23 @@ func genEnv(instance *v1alpha1.HigressGateway) []apiv1.EnvVar {
 		})
 	}
 
-	if instance.Spec.NetWorkGateway != "" {
+	if instance.Spec.NetworkGateway != "" {
 		envs = append(envs, apiv1.EnvVar{
 			Name:  "ISTIO_META_REQUESTED_NETWORK_VIEW",
-			Value: instance.Spec.NetWorkGateway,
+			Value: instance.Spec.NetworkGateway,
 		})
 	}
 
-	for k, v := range instance.Spec.Env {
-		envs = append(envs, apiv1.EnvVar{Name: k, Value: v})
-	}
+	envs = append(envs, instance.Spec.Env...)
 
 	return envs
 }
 
-func genImage(instance *v1alpha1.HigressGateway) string {
-	return fmt.Sprintf("%v:%v", instance.Spec.Image.Repository, instance.Spec.Image.Tag)
+func genImage(instance *v1beta1.HigressGateway) string {
+	return controller.ImageReference(instance.Spec.Image.Repository, instance.Spec.Image.Tag)
 }
 
-func genArgs(instance *v1alpha1.HigressGateway) []string {
+func genArgs(instance *v1beta1.HigressGateway) []string {
 	return []string{
 		"proxy",
 		"router",
@@ -272,7 +249,7 @@ func genArgs(instance *v1alpha1.HigressGateway) []string {
 }
 
 // todo(lql): Version adaptation
-func genSecurityContextForContainer(instance *v1alpha1.HigressGateway) *apiv1.SecurityContext {
+func genSecurityContextForContainer(instance *v1beta1.HigressGateway) *apiv1.SecurityContext {
 	if instance.Spec.SecurityContext != nil {
 		return instance.Spec.SecurityContext
 	}
@@ -293,8 +270,42 @@ func genSecurityContextForContainer(instance *v1alpha1.HigressGateway) *apiv1.Se
 	}
 }
 
+// haTopologyKeys are the topologies that the pods of a highly available gateway are spread over.
+var haTopologyKeys = []string{apiv1.LabelTopologyZone, apiv1.LabelHostname}
+
+// genTopologySpreadConstraints returns the topology spread constraints of the spec and, for a
+// highly available gateway, spreads the pods over the zones and nodes that the spec doesn't
+// constrain. The pods are scheduled anyway if they can't be spread, e.g. on a single node.
+func genTopologySpreadConstraints(instance *v1beta1.HigressGateway) []apiv1.TopologySpreadConstraint {
+	constraints := instance.Spec.TopologySpreadConstraints
+	if !instance.Spec.HighAvailability {
+		return constraints
+	}
+
+	keys := make(map[string]struct{}, len(constraints))
+	for _, c := range constraints {
+		keys[c.TopologyKey] = struct{}{}
+	}
+
+	constraints = append([]apiv1.TopologySpreadConstraint(nil), constraints...)
+	for _, key := range haTopologyKeys {
+		if _, ok := keys[key]; ok {
+			continue
+		}
+		constraints = append(constraints, apiv1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       key,
+			WhenUnsatisfiable: apiv1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: instance.Spec.SelectorLabels,
+			},
+		})
+	}
+	return constraints
+}
+
 // todo(lql): Version adaptation
-func genSecurityContextForPod(instance *v1alpha1.HigressGateway) *apiv1.PodSecurityContext {
+func genSecurityContextForPod(instance *v1beta1.HigressGateway) *apiv1.PodSecurityContext {
 	if instance.Spec.PodSecurityContext != nil {
 		return instance.Spec.PodSecurityContext
 	}
@@ -308,7 +319,7 @@ func genSecurityContextForPod(instance *v1alpha1.HigressGateway) *apiv1.PodSecur
 	}
 }
 
-func genVolumeMounts(instance *v1alpha1.HigressGateway) []apiv1.VolumeMount {
+func genVolumeMounts(instance *v1beta1.HigressGateway, binding *controllerBinding) []apiv1.VolumeMount {
 	mounts := []apiv1.VolumeMount{
 		{
 			Name:      "config",
@@ -332,7 +343,7 @@ func genVolumeMounts(instance *v1alpha1.HigressGateway) []apiv1.VolumeMount {
 		},
 	}
 
-	if instance.Spec.JwtPolicy == "third-party-jwt" {
+	if binding.JwtPolicy == "third-party-jwt" {
 		mounts = append(mounts, apiv1.VolumeMount{
 			Name:      "istio-token",
 			MountPath: "/var/run/secrets/tokens",
@@ -351,7 +362,7 @@ func genVolumeMounts(instance *v1alpha1.HigressGateway) []apiv1.VolumeMount {
 	return mounts
 }
 
-func genVolumes(instance *v1alpha1.HigressGateway) []apiv1.Volume {
+func genVolumes(instance *v1beta1.HigressGateway, binding *controllerBinding) []apiv1.Volume {
 	var volumes []apiv1.Volume
 
 	volumes = append(volumes, apiv1.Volume{
@@ -359,14 +370,14 @@ func genVolumes(instance *v1alpha1.HigressGateway) []apiv1.Volume {
 		VolumeSource: apiv1.VolumeSource{
 			ConfigMap: &apiv1.ConfigMapVolumeSource{
 				LocalObjectReference: apiv1.LocalObjectReference{
-					Name: controller.HigressGatewayConfig,
+					Name: getConfigMapName(instance),
 				},
 			},
 		},
 	})
 
 	caRootCertName := "higress-ca-root-cert"
-	if instance.Spec.EnableHigressIstio {
+	if binding.EnableHigressIstio {
 		caRootCertName = "istio-ca-root-cert"
 	}
 	mode := int32(420)
@@ -419,7 +430,7 @@ func genVolumes(instance *v1alpha1.HigressGateway) []apiv1.Volume {
 						{
 							Path: "cpu-request",
 							ResourceFieldRef: &apiv1.ResourceFieldSelector{
-								ContainerName: instanceName,
+								ContainerName: getContainerName(instance),
 								Divisor:       quantity,
 								Resource:      "requests.cpu",
 							},
@@ -427,7 +438,7 @@ func genVolumes(instance *v1alpha1.HigressGateway) []apiv1.Volume {
 						{
 							Path: "cpu-limit",
 							ResourceFieldRef: &apiv1.ResourceFieldSelector{
-								ContainerName: instanceName,
+								ContainerName: getContainerName(instance),
 								Divisor:       quantity,
 								Resource:      "limits.cpu",
 							},
@@ -438,7 +449,7 @@ func genVolumes(instance *v1alpha1.HigressGateway) []apiv1.Volume {
 		},
 	}...)
 
-	if instance.Spec.JwtPolicy == "third-party-jwt" {
+	if binding.JwtPolicy == "third-party-jwt" {
 		volumes = append(volumes, apiv1.Volume{
 			Name: "istio-token",
 			VolumeSource: apiv1.VolumeSource{
@@ -463,7 +474,7 @@ func genVolumes(instance *v1alpha1.HigressGateway) []apiv1.Volume {
 			VolumeSource: apiv1.VolumeSource{
 				ConfigMap: &apiv1.ConfigMapVolumeSource{
 					LocalObjectReference: apiv1.LocalObjectReference{
-						Name: "higress-custom-bootstrap",
+						Name: getSkywalkingConfigMapName(instance),
 					},
 					DefaultMode: &mode,
 				},
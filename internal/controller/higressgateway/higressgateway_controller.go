@@ -25,15 +25,19 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	operatorv1alpha1 "github.com/alibaba/higress/higress-operator/api/v1alpha1"
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
 	. "github.com/alibaba/higress/higress-operator/internal/controller"
 )
 
@@ -41,20 +45,39 @@ const (
 	finalizer = "higressgateway.higress.io/finalizer"
 )
 
+// reconcileStep creates or updates one kind of the objects managed for a HigressGateway.
+type reconcileStep struct {
+	name          string
+	failureReason string
+	run           func(context.Context, *operatorv1beta1.HigressGateway, logr.Logger) error
+}
+
 // HigressGatewayReconciler reconciles a HigressGateway object
 type HigressGatewayReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Config   *rest.Config
+	Recorder record.EventRecorder
+
+	// hpaVersion and pdbVersion are the autoscaling and policy API versions served by the cluster,
+	// resolved by SetupWithManager.
+	hpaVersion string
+	pdbVersion string
 }
 
 //+kubebuilder:rbac:groups=operator.higress.io,resources=higressgateways,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=operator.higress.io,resources=higressgateways/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=operator.higress.io,resources=higressgateways/finalizers,verbs=update
+//+kubebuilder:rbac:groups=operator.higress.io,resources=higresscontrollers,verbs=get;list;watch
 
 //+kubebuilder:rbac:groups="",resources=pods;services;services/finalizers;endpoints;persistentvolumeclaims;events;configmaps;secrets;serviceaccounts;namespaces,verbs=create;update;get;list;watch;patch;delete
 
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings;roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
 
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 // TODO(user): Modify the Reconcile function to compare the state specified by
@@ -67,7 +90,7 @@ type HigressGatewayReconciler struct {
 func (r *HigressGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
-	instance := &operatorv1alpha1.HigressGateway{}
+	instance := &operatorv1beta1.HigressGateway{}
 	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
 		if errors.IsNotFound(err) {
 			logger.Info(fmt.Sprintf("HigressGateway(%v) resource not found", req.NamespacedName))
@@ -78,14 +101,36 @@ func (r *HigressGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, err
 	}
 
-	r.setDefaultValues(instance)
+	// instances that were not admitted by the webhook
+	if recorded, err := RecordDefaultsVersion(ctx, r.Client, instance, instance.Status.Inventory,
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: instance.Namespace, Name: instance.Name}}, LatestDefaultsVersion); err != nil {
+		logger.Error(err, "Failed to record the defaults version")
+		return ctrl.Result{}, err
+	} else if recorded {
+		if err = r.Update(ctx, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// instances that were not defaulted by the webhook
+	SetDefaults(instance)
+
+	ctx = WithApplyOptions(ctx, ApplyOptions{
+		Owner:       instance,
+		Recorder:    r.Recorder,
+		DriftPolicy: instance.Spec.DriftPolicy,
+		// drifts can only be told apart from changes of the spec once the spec has been applied
+		DetectDrift: instance.Status.ObservedGeneration == instance.Generation,
+	})
 
 	// if deletionTimeStamp is not nil, it means is marked to be deleted
 	if instance.GetDeletionTimestamp() != nil {
 		if controllerutil.ContainsFinalizer(instance, finalizer) {
-			if err := r.finalizeHigressGateway(instance, logger); err != nil {
+			if err := r.finalizeHigressGateway(ctx, instance, logger); err != nil {
+				r.Recorder.Event(instance, apiv1.EventTypeWarning, ReasonFinalizeFailed, err.Error())
 				return ctrl.Result{}, err
 			}
+			r.Recorder.Eventf(instance, apiv1.EventTypeNormal, ReasonFinalized, "Finalized the instance with deletion policy %s", instance.Spec.DeletionPolicy)
 
 			controllerutil.RemoveFinalizer(instance, finalizer)
 
@@ -115,66 +160,144 @@ func (r *HigressGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		}
 	}
 
-	if err = r.createServiceAccount(ctx, instance, logger); err != nil {
-		return ctrl.Result{}, err
+	var binding *controllerBinding
+	if err = ObserveStep("HigressGateway", StepControllerRef, func() (err error) {
+		binding, err = resolveControllerBinding(ctx, r.Client, instance)
+		return err
+	}); err != nil {
+		return r.reconcileFailed(ctx, instance, ReasonControllerRefFailed, err, logger)
+	}
+	ctx = withControllerBinding(ctx, binding)
+
+	for _, step := range []reconcileStep{
+		{StepServiceAccount, ReasonServiceAccountFailed, r.createServiceAccount},
+		{StepRBAC, ReasonRBACFailed, r.createRBAC},
+		{StepConfigMap, ReasonConfigMapFailed, r.createConfigMap},
+		{StepDeployment, ReasonDeploymentFailed, r.createDeployment},
+		{StepService, ReasonServiceFailed, r.createService},
+		{StepAutoScaling, ReasonAutoScalingFailed, r.createHPA},
+		{StepDisruption, ReasonDisruptionFailed, r.createPDB},
+		{StepPrune, ReasonPruneFailed, r.prune},
+	} {
+		if err = ObserveStep("HigressGateway", step.name, func() error {
+			return step.run(ctx, instance, logger)
+		}); err != nil {
+			return r.reconcileFailed(ctx, instance, step.failureReason, err, logger)
+		}
 	}
 
-	if err = r.createRBAC(ctx, instance, logger); err != nil {
+	ClearReconcileError(&instance.Status.Conditions, instance.Generation)
+	instance.Status.ObservedGeneration = instance.Generation
+	if err = r.updateStatus(ctx, instance); err != nil {
+		logger.Error(err, "Failed to update higressGateway/status")
 		return ctrl.Result{}, err
 	}
 
-	if err = r.createConfigMap(ctx, instance, logger); err != nil {
-		return ctrl.Result{}, err
-	}
+	// objects that are not watched, such as the RBAC objects, are checked for drifts periodically
+	return ctrl.Result{RequeueAfter: DriftCheckInterval}, nil
+}
 
-	if err = r.createDeployment(ctx, instance, logger); err != nil {
-		return ctrl.Result{}, err
+// reconcileFailed records the failed step in the status and returns err to requeue the request.
+func (r *HigressGatewayReconciler) reconcileFailed(ctx context.Context, instance *operatorv1beta1.HigressGateway,
+	reason string, err error, logger logr.Logger) (ctrl.Result, error) {
+	r.Recorder.Event(instance, apiv1.EventTypeWarning, reason, err.Error())
+	SetReconcileError(&instance.Status.Conditions, instance.Generation, reason, err)
+	if statusErr := r.updateStatus(ctx, instance); statusErr != nil {
+		logger.Error(statusErr, "Failed to update higressGateway/status")
 	}
+	return ctrl.Result{}, err
+}
 
-	if err = r.createService(ctx, instance, logger); err != nil {
-		return ctrl.Result{}, err
-	}
+// updateStatus refreshes the conditions derived from the gateway Deployment and the field
+// conflicts of the reconcile, and writes the status.
+func (r *HigressGatewayReconciler) updateStatus(ctx context.Context, instance *operatorv1beta1.HigressGateway) error {
+	SetFieldConflicts(&instance.Status.Conditions, instance.Generation, FieldConflicts(ctx))
 
-	if !instance.Status.Deployed {
-		instance.Status.Deployed = true
-		if err = r.Status().Update(ctx, instance); err != nil {
-			return ctrl.Result{}, err
+	deploy := &appsv1.Deployment{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(instance), deploy); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
 		}
+		deploy = nil
 	}
 
-	return ctrl.Result{}, nil
+	SetDeploymentConditions(&instance.Status.Conditions, instance.Generation, deploy)
+	setScaleStatus(instance, deploy)
+	return r.Status().Update(ctx, instance)
+}
+
+// setScaleStatus records the replicas and the pod selector of the gateway Deployment, which the
+// scale subresource of the instance reports.
+func setScaleStatus(instance *operatorv1beta1.HigressGateway, deploy *appsv1.Deployment) {
+	instance.Status.Replicas, instance.Status.Selector = 0, ""
+	if deploy == nil {
+		return
+	}
+
+	instance.Status.Replicas = deploy.Status.Replicas
+	if selector, err := metav1.LabelSelectorAsSelector(deploy.Spec.Selector); err == nil {
+		instance.Status.Selector = selector.String()
+	}
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *HigressGatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// only the autoscaling version served by the cluster can be watched, the reconciles apply the same one
+	var err error
+	if r.hpaVersion, err = getHPAVersion(mgr.GetConfig()); err != nil {
+		return err
+	}
+	if r.pdbVersion, err = GetPDBVersion(mgr.GetConfig()); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&operatorv1alpha1.HigressGateway{}).
+		For(&operatorv1beta1.HigressGateway{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&apiv1.Service{}).
 		Owns(&apiv1.ConfigMap{}).
 		Owns(&apiv1.ServiceAccount{}).
+		Owns(newHPA(r.hpaVersion)).
+		Owns(NewPDB(r.pdbVersion)).
+		Watches(&operatorv1beta1.HigressController{}, handler.EnqueueRequestsFromMapFunc(r.gatewaysOfController)).
 		Complete(r)
 }
 
-func (r *HigressGatewayReconciler) createServiceAccount(ctx context.Context, instance *operatorv1alpha1.HigressGateway, logger logr.Logger) error {
-	sa := initServiceAccount(&apiv1.ServiceAccount{}, instance)
-	if err := ctrl.SetControllerReference(instance, sa, r.Scheme); err != nil {
-		return err
+// gatewaysOfController returns the requests of the gateways bound to the HigressController obj.
+func (r *HigressGatewayReconciler) gatewaysOfController(ctx context.Context, obj client.Object) []reconcile.Request {
+	gateways := &operatorv1beta1.HigressGatewayList{}
+	if err := r.List(ctx, gateways); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list higressGateways")
+		return nil
 	}
 
-	exists, err := CreateIfNotExits(ctx, r.Client, sa)
-	if err != nil {
-		return err
+	var requests []reconcile.Request
+	for _, gateway := range gateways.Items {
+		ref := gateway.Spec.ControllerRef
+		if ref == nil || ref.Name != obj.GetName() {
+			continue
+		}
+		if ref.Namespace == obj.GetNamespace() || (ref.Namespace == "" && gateway.Namespace == obj.GetNamespace()) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&gateway)})
+		}
 	}
+	return requests
+}
 
-	if !exists {
-		logger.Info(fmt.Sprintf("create serviceAccount for HigressGateway(%v)", instance.Name))
+func (r *HigressGatewayReconciler) createServiceAccount(ctx context.Context, instance *operatorv1beta1.HigressGateway, logger logr.Logger) error {
+	if !instance.Spec.ServiceAccount.Enable {
+		return nil
 	}
 
-	return nil
+	sa := initServiceAccount(&apiv1.ServiceAccount{}, instance)
+	if err := ctrl.SetControllerReference(instance, sa, r.Scheme); err != nil {
+		return err
+	}
+
+	return Apply(ctx, r.Client, "ServiceAccount", sa, logger)
 }
 
-func (r *HigressGatewayReconciler) createRBAC(ctx context.Context, instance *operatorv1alpha1.HigressGateway, logger logr.Logger) error {
+func (r *HigressGatewayReconciler) createRBAC(ctx context.Context, instance *operatorv1beta1.HigressGateway, logger logr.Logger) error {
 	if !instance.Spec.RBAC.Enable || !instance.Spec.ServiceAccount.Enable {
 		return nil
 	}
@@ -188,85 +311,138 @@ func (r *HigressGatewayReconciler) createRBAC(ctx context.Context, instance *ope
 	)
 	// reconcile clusterRole
 	cr = initClusterRole(cr, instance)
-	if err = CreateOrUpdate(ctx, r.Client, "clusterrole", cr, muteClusterRole(cr, instance), logger); err != nil {
+	if err = Apply(ctx, r.Client, "clusterrole", cr, logger); err != nil {
 		return err
 	}
 
-	// reconcile clusterRoleBinding
+	// reconcile clusterRoleBinding, the subjects of the live binding are merged
+	if err = r.Get(ctx, types.NamespacedName{Name: getClusterRoleName(instance)}, crb); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
 	initClusterRoleBinding(crb, instance)
-	if err = CreateOrUpdate(ctx, r.Client, "clusterRoleBinding", crb,
-		muteClusterRoleBinding(crb, instance), logger); err != nil {
+	if err = Apply(ctx, r.Client, "clusterRoleBinding", crb, logger); err != nil {
 		return err
 	}
 
 	initRole(role, instance)
-	if err = CreateOrUpdate(ctx, r.Client, "role", role, muteRole(role, instance), logger); err != nil {
+	if err = Apply(ctx, r.Client, "role", role, logger); err != nil {
 		return err
 	}
 
 	initRoleBinding(rb, instance)
-	if err = CreateOrUpdate(ctx, r.Client, "roleBinding", rb,
-		muteRoleBinding(rb, instance), logger); err != nil {
+	if err = Apply(ctx, r.Client, "roleBinding", rb, logger); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (r *HigressGatewayReconciler) createDeployment(ctx context.Context, instance *operatorv1alpha1.HigressGateway, logger logr.Logger) error {
-	deploy := initDeployment(&appsv1.Deployment{}, instance)
+func (r *HigressGatewayReconciler) createDeployment(ctx context.Context, instance *operatorv1beta1.HigressGateway, logger logr.Logger) error {
+	deploy := initDeployment(&appsv1.Deployment{}, instance, getControllerBinding(ctx, instance))
 	if err := ctrl.SetControllerReference(instance, deploy, r.Scheme); err != nil {
 		return err
 	}
 
-	return CreateOrUpdate(ctx, r.Client, "Deployment", deploy, muteDeployment(deploy, instance), logger)
+	return Apply(ctx, r.Client, "Deployment", deploy, logger)
 }
 
-func (r *HigressGatewayReconciler) createService(ctx context.Context, instance *operatorv1alpha1.HigressGateway, logger logr.Logger) error {
+func (r *HigressGatewayReconciler) createService(ctx context.Context, instance *operatorv1beta1.HigressGateway, logger logr.Logger) error {
 	svc := initService(&apiv1.Service{}, instance)
 	if err := ctrl.SetControllerReference(instance, svc, r.Scheme); err != nil {
 		return err
 	}
 
-	return CreateOrUpdate(ctx, r.Client, "Service", svc, muteService(svc, instance), logger)
+	return Apply(ctx, r.Client, "Service", svc, logger)
 }
 
-func (r *HigressGatewayReconciler) finalizeHigressGateway(instance *operatorv1alpha1.HigressGateway, logger logr.Logger) error {
-	var (
-		ctx = context.TODO()
-		crb = &rbacv1.ClusterRoleBinding{}
-	)
+func (r *HigressGatewayReconciler) createHPA(ctx context.Context, instance *operatorv1beta1.HigressGateway, logger logr.Logger) error {
+	// the HorizontalPodAutoscaler is pruned once it is disabled
+	if !AutoScalingEnabled(&instance.Spec.CRDCommonFields) {
+		instance.Status.AutoScaling = nil
+		return nil
+	}
 
-	name := getServiceAccount(instance)
-	nn := types.NamespacedName{Name: name, Namespace: apiv1.NamespaceAll}
-	if err := r.Get(ctx, nn, crb); err != nil {
+	hpa, err := initHPA(r.hpaVersion, instance)
+	if err != nil {
 		return err
 	}
-
-	var subjects []rbacv1.Subject
-	for _, subject := range crb.Subjects {
-		if subject.Name != name || subject.Namespace != instance.Namespace {
-			subjects = append(subjects, subject)
-		}
+	if err = ctrl.SetControllerReference(instance, hpa, r.Scheme); err != nil {
+		return err
 	}
-	crb.Subjects = subjects
-	if err := r.Update(ctx, crb); err != nil {
+
+	if err = Apply(ctx, r.Client, "HorizontalPodAutoscaler", hpa, logger); err != nil {
 		return err
 	}
 
+	instance.Status.AutoScaling = getHPAStatus(hpa)
 	return nil
 }
 
-func (r *HigressGatewayReconciler) createConfigMap(ctx context.Context, instance *operatorv1alpha1.HigressGateway, logger logr.Logger) error {
-	gatewayConfigMap, err := initGatewayConfigMap(&apiv1.ConfigMap{}, instance)
+// createPDB applies the PodDisruptionBudget of the gateway pods in the policy version served by the
+// cluster, it is pruned once it is disabled.
+func (r *HigressGatewayReconciler) createPDB(ctx context.Context, instance *operatorv1beta1.HigressGateway, logger logr.Logger) error {
+	if !PDBEnabled(&instance.Spec.CRDCommonFields) {
+		return nil
+	}
+
+	pdb := InitPDB(r.pdbVersion, metav1.ObjectMeta{
+		Name:      instance.Name,
+		Namespace: instance.Namespace,
+		Labels:    instance.Labels,
+	}, &instance.Spec.CRDCommonFields)
+	if err := ctrl.SetControllerReference(instance, pdb, r.Scheme); err != nil {
+		return err
+	}
+
+	return Apply(ctx, r.Client, "PodDisruptionBudget", pdb, logger)
+}
+
+// finalizeHigressGateway deletes the objects of the instance, including its subjects of the shared
+// ClusterRoleBinding, or keeps them running without the instance if the deletion policy is Retain.
+// Objects that are already gone are skipped, so that the instance can always be deleted.
+func (r *HigressGatewayReconciler) finalizeHigressGateway(ctx context.Context, instance *operatorv1beta1.HigressGateway, logger logr.Logger) error {
+	if instance.Spec.DeletionPolicy == operatorv1beta1.DeletionPolicyRetain {
+		return Orphan(ctx, r.Client, instance, instance.Status.Inventory, logger)
+	}
+
+	if err := Prune(ctx, r.Client, instance.Status.Inventory, logger); err != nil {
+		return err
+	}
+
+	// instances created before the inventory was recorded only have the ClusterRoleBinding to clean up
+	return DeleteClusterRBAC(ctx, r.Client, getClusterRoleName(instance), getClusterRoleName(instance), instance.Namespace, logger)
+}
+
+func (r *HigressGatewayReconciler) createConfigMap(ctx context.Context, instance *operatorv1beta1.HigressGateway, logger logr.Logger) error {
+	binding := getControllerBinding(ctx, instance)
+	gatewayConfigMap, err := initGatewayConfigMap(&apiv1.ConfigMap{}, instance, binding)
 	if err != nil {
 		return err
 	}
-	if err = CreateOrUpdate(ctx, r.Client, "gatewayConfigMap", gatewayConfigMap,
-		muteConfigMap(gatewayConfigMap, instance, updateGatewayConfigMapSpec), logger); err != nil {
+	if err = Apply(ctx, r.Client, "gatewayConfigMap", gatewayConfigMap, logger); err != nil {
 		return err
 	}
 
+	// the controller mounts the mesh config from its own namespace, the copy is recorded in the
+	// inventory so that it is deleted with the gateway
+	if binding.Namespace != instance.Namespace {
+		controllerConfigMap, err := initGatewayConfigMap(&apiv1.ConfigMap{}, instance, binding)
+		if err != nil {
+			return err
+		}
+
+		controllerConfigMap.Name = RemoteGatewayConfigName(instance.Namespace, instance.Name)
+		controllerConfigMap.Namespace = binding.Namespace
+		controllerConfigMap.Labels = make(map[string]string, len(instance.Labels)+1)
+		for k, v := range instance.Labels {
+			controllerConfigMap.Labels[k] = v
+		}
+		controllerConfigMap.Labels[LabelGatewayConfigOf] = binding.Controller
+		if err = Apply(ctx, r.Client, "gatewayConfigMap", controllerConfigMap, logger); err != nil {
+			return err
+		}
+	}
+
 	if instance.Spec.Skywalking.Enable {
 		skywalkingConfigMap, err := initSkywalkingConfigMap(&apiv1.ConfigMap{}, instance)
 		if err != nil {
@@ -277,8 +453,7 @@ func (r *HigressGatewayReconciler) createConfigMap(ctx context.Context, instance
 			return err
 		}
 
-		if err = CreateOrUpdate(ctx, r.Client, "skywalkingConfigMap", skywalkingConfigMap,
-			muteConfigMap(skywalkingConfigMap, instance, updateSkywalkingConfigMap), logger); err != nil {
+		if err = Apply(ctx, r.Client, "skywalkingConfigMap", skywalkingConfigMap, logger); err != nil {
 			return err
 		}
 	}
@@ -286,48 +461,13 @@ func (r *HigressGatewayReconciler) createConfigMap(ctx context.Context, instance
 	return nil
 }
 
-func (r *HigressGatewayReconciler) setDefaultValues(instance *operatorv1alpha1.HigressGateway) {
-	if instance.Spec.RBAC == nil {
-		instance.Spec.RBAC = &operatorv1alpha1.RBAC{Enable: true}
-	}
-	// serviceAccount
-	if instance.Spec.ServiceAccount == nil {
-		instance.Spec.ServiceAccount = &operatorv1alpha1.ServiceAccount{Enable: true, Name: "higress-gateway"}
-	}
-	// replicas
-	if instance.Spec.Replicas == nil {
-		replicas := int32(1)
-		instance.Spec.Replicas = &replicas
-	}
-	// selectorLabels
-	if len(instance.Spec.SelectorLabels) == 0 {
-		instance.Spec.SelectorLabels = map[string]string{
-			"app":     "higress-gateway",
-			"higress": "higress-system-higress-gateway",
-		}
-	}
-	// service
-	if instance.Spec.Service == nil {
-		instance.Spec.Service = &operatorv1alpha1.Service{
-			Type: "LoadBalancer",
-			Ports: []apiv1.ServicePort{
-				{
-					Name:       "http2",
-					Port:       80,
-					Protocol:   "TCP",
-					TargetPort: intstr.FromInt(80),
-				},
-				{
-					Name:       "https",
-					Port:       443,
-					Protocol:   "TCP",
-					TargetPort: intstr.FromInt(443),
-				},
-			},
-		}
-	}
-	// skywalking
-	if instance.Spec.Skywalking == nil {
-		instance.Spec.Skywalking = &operatorv1alpha1.Skywalking{Enable: false}
+// prune deletes the objects of the inventory that were not applied in this reconcile and
+// records the new inventory.
+func (r *HigressGatewayReconciler) prune(ctx context.Context, instance *operatorv1beta1.HigressGateway, logger logr.Logger) error {
+	if err := Prune(ctx, r.Client, instance.Status.Inventory, logger); err != nil {
+		return err
 	}
+
+	instance.Status.Inventory = Inventory(ctx)
+	return nil
 }
@@ -1,58 +1,140 @@
 package higressgateway
 
 import (
+	"fmt"
+
 	"k8s.io/api/autoscaling/v2"
 	"k8s.io/api/autoscaling/v2beta1"
 	"k8s.io/api/autoscaling/v2beta2"
+	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+	"github.com/alibaba/higress/higress-operator/internal/controller"
+)
 
-	"github.com/alibaba/higress/higress-operator/api/v1alpha1"
+const (
+	autoscalingV2      = "v2"
+	autoscalingV2beta2 = "v2beta2"
+	autoscalingV2beta1 = "v2beta1"
 )
 
-func initHPAv2(hpa *v2.HorizontalPodAutoscaler, instance *v1alpha1.HigressGateway) *v2.HorizontalPodAutoscaler {
+// getHPAVersion returns the newest autoscaling API version served by the cluster.
+func getHPAVersion(cfg *rest.Config) (string, error) {
+	return controller.ServerPreferredVersion(cfg, "autoscaling", autoscalingV2, autoscalingV2beta2, autoscalingV2beta1)
+}
+
+// newHPA returns an empty HorizontalPodAutoscaler of the given autoscaling API version.
+func newHPA(version string) client.Object {
+	switch version {
+	case autoscalingV2beta2:
+		return &v2beta2.HorizontalPodAutoscaler{}
+	case autoscalingV2beta1:
+		return &v2beta1.HorizontalPodAutoscaler{}
+	default:
+		return &v2.HorizontalPodAutoscaler{}
+	}
+}
+
+// initHPA renders the HorizontalPodAutoscaler of instance in the given autoscaling API version.
+// It fails if the spec uses features that the version can't express.
+func initHPA(version string, instance *v1beta1.HigressGateway) (client.Object, error) {
+	hpa := initHPAv2(&v2.HorizontalPodAutoscaler{}, instance)
+	switch version {
+	case autoscalingV2beta2:
+		return convertV2ToV2beta2(hpa), nil
+	case autoscalingV2beta1:
+		return convertV2ToV2beta1(hpa)
+	default:
+		return hpa, nil
+	}
+}
+
+func initHPAv2(hpa *v2.HorizontalPodAutoscaler, instance *v1beta1.HigressGateway) *v2.HorizontalPodAutoscaler {
 	*hpa = v2.HorizontalPodAutoscaler{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        instance.Name,
 			Namespace:   instance.Namespace,
 			Labels:      instance.Labels,
-			Annotations: instance.Annotations,
-		},
-		Spec: v2.HorizontalPodAutoscalerSpec{
-			MaxReplicas: instance.Spec.AutoScaling.MaxReplicas,
-			MinReplicas: instance.Spec.AutoScaling.MinReplicas,
-			ScaleTargetRef: v2.CrossVersionObjectReference{
-				Kind:       "Deployment",
-				Name:       instance.Name,
-				APIVersion: "apps/v1",
-			},
-			Metrics: []v2.MetricSpec{
-				{
-					Type: v2.ResourceMetricSourceType,
-					Resource: &v2.ResourceMetricSource{
-						Name: "cpu",
-						Target: v2.MetricTarget{
-							Type:               v2.UtilizationMetricType,
-							AverageUtilization: instance.Spec.AutoScaling.TargetCPUUtilizationPercentage,
-						},
-					},
-				},
-			},
+			Annotations: controller.ObjectAnnotations(instance),
 		},
 	}
 
+	updateHPAv2Spec(hpa, instance)
 	return hpa
 }
 
-func muteHPA(hpa *v2.HorizontalPodAutoscaler, instance *v1alpha1.HigressGateway) controllerutil.MutateFn {
-	return func() error {
-		initHPAv2(hpa, instance)
-		return nil
+func updateHPAv2Spec(hpa *v2.HorizontalPodAutoscaler, instance *v1beta1.HigressGateway) {
+	hpa.Spec = v2.HorizontalPodAutoscalerSpec{
+		MaxReplicas: instance.Spec.AutoScaling.MaxReplicas,
+		MinReplicas: instance.Spec.AutoScaling.MinReplicas,
+		ScaleTargetRef: v2.CrossVersionObjectReference{
+			Kind:       "Deployment",
+			Name:       instance.Name,
+			APIVersion: "apps/v1",
+		},
+	}
+
+	// the api server falls back to 80% cpu utilization if no metric is specified
+	if target := instance.Spec.AutoScaling.TargetCPUUtilizationPercentage; target != nil {
+		hpa.Spec.Metrics = append(hpa.Spec.Metrics, genUtilizationMetric(apiv1.ResourceCPU, target))
 	}
+	if target := instance.Spec.AutoScaling.TargetMemoryUtilizationPercentage; target != nil {
+		hpa.Spec.Metrics = append(hpa.Spec.Metrics, genUtilizationMetric(apiv1.ResourceMemory, target))
+	}
+	hpa.Spec.Metrics = append(hpa.Spec.Metrics, instance.Spec.AutoScaling.Metrics...)
+
+	hpa.Spec.Behavior = instance.Spec.AutoScaling.Behavior
+}
+
+func genUtilizationMetric(name apiv1.ResourceName, target *int32) v2.MetricSpec {
+	return v2.MetricSpec{
+		Type: v2.ResourceMetricSourceType,
+		Resource: &v2.ResourceMetricSource{
+			Name: name,
+			Target: v2.MetricTarget{
+				Type:               v2.UtilizationMetricType,
+				AverageUtilization: target,
+			},
+		},
+	}
+}
+
+// getHPAStatus returns the replicas chosen by the autoscaler.
+func getHPAStatus(hpa client.Object) *v1beta1.AutoScalingStatus {
+	switch h := hpa.(type) {
+	case *v2.HorizontalPodAutoscaler:
+		return &v1beta1.AutoScalingStatus{
+			CurrentReplicas: h.Status.CurrentReplicas,
+			DesiredReplicas: h.Status.DesiredReplicas,
+			LastScaleTime:   h.Status.LastScaleTime,
+		}
+	case *v2beta2.HorizontalPodAutoscaler:
+		return &v1beta1.AutoScalingStatus{
+			CurrentReplicas: h.Status.CurrentReplicas,
+			DesiredReplicas: h.Status.DesiredReplicas,
+			LastScaleTime:   h.Status.LastScaleTime,
+		}
+	case *v2beta1.HorizontalPodAutoscaler:
+		return &v1beta1.AutoScalingStatus{
+			CurrentReplicas: h.Status.CurrentReplicas,
+			DesiredReplicas: h.Status.DesiredReplicas,
+			LastScaleTime:   h.Status.LastScaleTime,
+		}
+	}
+	return nil
 }
 
-func convertV2ToV2beta1(hpa *v2.HorizontalPodAutoscaler) (res *v2beta1.HorizontalPodAutoscaler) {
-	res = &v2beta1.HorizontalPodAutoscaler{
+func convertV2ToV2beta1(hpa *v2.HorizontalPodAutoscaler) (*v2beta1.HorizontalPodAutoscaler, error) {
+	// autoscaling/v2beta1 has no scaling behavior
+	if hpa.Spec.Behavior != nil {
+		return nil, fmt.Errorf("autoScaling.behavior requires autoscaling/v2beta2 or newer, " +
+			"but the cluster only serves autoscaling/v2beta1")
+	}
+
+	res := &v2beta1.HorizontalPodAutoscaler{
 		ObjectMeta: hpa.ObjectMeta,
 		Spec: v2beta1.HorizontalPodAutoscalerSpec{
 			MinReplicas:    hpa.Spec.MinReplicas,
@@ -62,17 +144,59 @@ func convertV2ToV2beta1(hpa *v2.HorizontalPodAutoscaler) (res *v2beta1.Horizonta
 	}
 
 	for _, metric := range hpa.Spec.Metrics {
-		res.Spec.Metrics = append(res.Spec.Metrics, v2beta1.MetricSpec{
-			Type: v2beta1.MetricSourceType(metric.Type),
-			Resource: &v2beta1.ResourceMetricSource{
+		item := v2beta1.MetricSpec{Type: v2beta1.MetricSourceType(metric.Type)}
+
+		switch {
+		case metric.Resource != nil:
+			item.Resource = &v2beta1.ResourceMetricSource{
 				Name:                     metric.Resource.Name,
 				TargetAverageUtilization: metric.Resource.Target.AverageUtilization,
 				TargetAverageValue:       metric.Resource.Target.AverageValue,
-			},
-		})
+			}
+		case metric.ContainerResource != nil:
+			item.ContainerResource = &v2beta1.ContainerResourceMetricSource{
+				Name:                     metric.ContainerResource.Name,
+				Container:                metric.ContainerResource.Container,
+				TargetAverageUtilization: metric.ContainerResource.Target.AverageUtilization,
+				TargetAverageValue:       metric.ContainerResource.Target.AverageValue,
+			}
+		case metric.Pods != nil:
+			if metric.Pods.Target.AverageValue == nil {
+				return nil, fmt.Errorf("pods metric %q requires an averageValue target on autoscaling/v2beta1",
+					metric.Pods.Metric.Name)
+			}
+			item.Pods = &v2beta1.PodsMetricSource{
+				MetricName:         metric.Pods.Metric.Name,
+				Selector:           metric.Pods.Metric.Selector,
+				TargetAverageValue: *metric.Pods.Target.AverageValue,
+			}
+		case metric.Object != nil:
+			if metric.Object.Target.Value == nil {
+				return nil, fmt.Errorf("object metric %q requires a value target on autoscaling/v2beta1",
+					metric.Object.Metric.Name)
+			}
+			item.Object = &v2beta1.ObjectMetricSource{
+				Target:       v2beta1.CrossVersionObjectReference(metric.Object.DescribedObject),
+				MetricName:   metric.Object.Metric.Name,
+				Selector:     metric.Object.Metric.Selector,
+				TargetValue:  *metric.Object.Target.Value,
+				AverageValue: metric.Object.Target.AverageValue,
+			}
+		case metric.External != nil:
+			item.External = &v2beta1.ExternalMetricSource{
+				MetricName:         metric.External.Metric.Name,
+				MetricSelector:     metric.External.Metric.Selector,
+				TargetValue:        metric.External.Target.Value,
+				TargetAverageValue: metric.External.Target.AverageValue,
+			}
+		default:
+			continue
+		}
+
+		res.Spec.Metrics = append(res.Spec.Metrics, item)
 	}
 
-	return
+	return res, nil
 }
 
 func convertV2ToV2beta2(hpa *v2.HorizontalPodAutoscaler) (res *v2beta2.HorizontalPodAutoscaler) {
@@ -86,79 +210,81 @@ func convertV2ToV2beta2(hpa *v2.HorizontalPodAutoscaler) (res *v2beta2.Horizonta
 	}
 
 	for _, metric := range hpa.Spec.Metrics {
-		res.Spec.Metrics = append(res.Spec.Metrics, v2beta2.MetricSpec{
-			Type: v2beta2.MetricSourceType(metric.Type),
-			Resource: &v2beta2.ResourceMetricSource{
-				Name: metric.Resource.Name,
-				Target: v2beta2.MetricTarget{
-					Type:               v2beta2.MetricTargetType(metric.Resource.Target.Type),
-					Value:              metric.Resource.Target.Value,
-					AverageValue:       metric.Resource.Target.AverageValue,
-					AverageUtilization: metric.Resource.Target.AverageUtilization,
-				},
-			},
-		})
-	}
+		item := v2beta2.MetricSpec{Type: v2beta2.MetricSourceType(metric.Type)}
 
-	return
-}
+		switch {
+		case metric.Resource != nil:
+			item.Resource = &v2beta2.ResourceMetricSource{
+				Name:   metric.Resource.Name,
+				Target: convertV2TargetToV2beta2(metric.Resource.Target),
+			}
+		case metric.ContainerResource != nil:
+			item.ContainerResource = &v2beta2.ContainerResourceMetricSource{
+				Name:      metric.ContainerResource.Name,
+				Container: metric.ContainerResource.Container,
+				Target:    convertV2TargetToV2beta2(metric.ContainerResource.Target),
+			}
+		case metric.Pods != nil:
+			item.Pods = &v2beta2.PodsMetricSource{
+				Metric: v2beta2.MetricIdentifier(metric.Pods.Metric),
+				Target: convertV2TargetToV2beta2(metric.Pods.Target),
+			}
+		case metric.Object != nil:
+			item.Object = &v2beta2.ObjectMetricSource{
+				DescribedObject: v2beta2.CrossVersionObjectReference(metric.Object.DescribedObject),
+				Metric:          v2beta2.MetricIdentifier(metric.Object.Metric),
+				Target:          convertV2TargetToV2beta2(metric.Object.Target),
+			}
+		case metric.External != nil:
+			item.External = &v2beta2.ExternalMetricSource{
+				Metric: v2beta2.MetricIdentifier(metric.External.Metric),
+				Target: convertV2TargetToV2beta2(metric.External.Target),
+			}
+		default:
+			continue
+		}
 
-func convertV2beta2ToV2(hpa *v2beta2.HorizontalPodAutoscaler) (res *v2.HorizontalPodAutoscaler) {
-	res = &v2.HorizontalPodAutoscaler{
-		ObjectMeta: hpa.ObjectMeta,
-		Spec: v2.HorizontalPodAutoscalerSpec{
-			MinReplicas:    hpa.Spec.MinReplicas,
-			MaxReplicas:    hpa.Spec.MaxReplicas,
-			ScaleTargetRef: v2.CrossVersionObjectReference(hpa.Spec.ScaleTargetRef),
-		},
+		res.Spec.Metrics = append(res.Spec.Metrics, item)
 	}
 
-	for _, metric := range hpa.Spec.Metrics {
-		res.Spec.Metrics = append(res.Spec.Metrics, v2.MetricSpec{
-			Type: v2.MetricSourceType(metric.Type),
-			Resource: &v2.ResourceMetricSource{
-				Name: metric.Resource.Name,
-				Target: v2.MetricTarget{
-					Type:               v2.MetricTargetType(metric.Resource.Target.Type),
-					Value:              metric.Resource.Target.Value,
-					AverageValue:       metric.Resource.Target.AverageValue,
-					AverageUtilization: metric.Resource.Target.AverageUtilization,
-				},
-			},
-		})
+	if behavior := hpa.Spec.Behavior; behavior != nil {
+		res.Spec.Behavior = &v2beta2.HorizontalPodAutoscalerBehavior{
+			ScaleUp:   convertV2ScalingRulesToV2beta2(behavior.ScaleUp),
+			ScaleDown: convertV2ScalingRulesToV2beta2(behavior.ScaleDown),
+		}
 	}
 
 	return
 }
 
-func convertV2beta1ToV2(hpa *v2beta1.HorizontalPodAutoscaler) (res *v2.HorizontalPodAutoscaler) {
-	res = &v2.HorizontalPodAutoscaler{
-		ObjectMeta: hpa.ObjectMeta,
-		Spec: v2.HorizontalPodAutoscalerSpec{
-			MinReplicas:    hpa.Spec.MinReplicas,
-			MaxReplicas:    hpa.Spec.MaxReplicas,
-			ScaleTargetRef: v2.CrossVersionObjectReference(hpa.Spec.ScaleTargetRef),
-		},
+func convertV2TargetToV2beta2(target v2.MetricTarget) v2beta2.MetricTarget {
+	return v2beta2.MetricTarget{
+		Type:               v2beta2.MetricTargetType(target.Type),
+		Value:              target.Value,
+		AverageValue:       target.AverageValue,
+		AverageUtilization: target.AverageUtilization,
 	}
+}
 
-	for _, metric := range hpa.Spec.Metrics {
-		item := v2.MetricSpec{
-			Type: v2.MetricSourceType(metric.Type),
-			Resource: &v2.ResourceMetricSource{
-				Name: metric.Resource.Name,
-			},
-		}
-
-		if metric.Resource.TargetAverageUtilization != nil {
-			item.Resource.Target.Type = v2.UtilizationMetricType
-			item.Resource.Target.AverageUtilization = metric.Resource.TargetAverageUtilization
-		} else if metric.Resource.TargetAverageValue != nil {
-			item.Resource.Target.Type = v2.AverageValueMetricType
-			item.Resource.Target.AverageValue = metric.Resource.TargetAverageValue
-		}
+func convertV2ScalingRulesToV2beta2(rules *v2.HPAScalingRules) *v2beta2.HPAScalingRules {
+	if rules == nil {
+		return nil
+	}
 
-		res.Spec.Metrics = append(res.Spec.Metrics, item)
+	res := &v2beta2.HPAScalingRules{
+		StabilizationWindowSeconds: rules.StabilizationWindowSeconds,
+	}
+	if rules.SelectPolicy != nil {
+		policy := v2beta2.ScalingPolicySelect(*rules.SelectPolicy)
+		res.SelectPolicy = &policy
+	}
+	for _, policy := range rules.Policies {
+		res.Policies = append(res.Policies, v2beta2.HPAScalingPolicy{
+			Type:          v2beta2.HPAScalingPolicyType(policy.Type),
+			Value:         policy.Value,
+			PeriodSeconds: policy.PeriodSeconds,
+		})
 	}
 
-	return
+	return res
 }
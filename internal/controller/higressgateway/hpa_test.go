@@ -0,0 +1,195 @@
+package higressgateway
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"k8s.io/api/autoscaling/v2"
+	"k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/api/autoscaling/v2beta2"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
+func newAutoScalingGateway(autoScaling *v1beta1.AutoScaling) *v1beta1.HigressGateway {
+	return &v1beta1.HigressGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "higress-gateway", Namespace: "higress-system"},
+		Spec: v1beta1.HigressGatewaySpec{
+			CRDCommonFields: v1beta1.CRDCommonFields{AutoScaling: autoScaling},
+		},
+	}
+}
+
+func TestConvertV2ToV2beta1(t *testing.T) {
+	value := resource.MustParse("100")
+	hpa := initHPAv2(&v2.HorizontalPodAutoscaler{}, newAutoScalingGateway(&v1beta1.AutoScaling{
+		Enable:                         true,
+		MinReplicas:                    int32Ptr(2),
+		MaxReplicas:                    5,
+		TargetCPUUtilizationPercentage: int32Ptr(80),
+		Metrics: []v2.MetricSpec{
+			{
+				Type: v2.PodsMetricSourceType,
+				Pods: &v2.PodsMetricSource{
+					Metric: v2.MetricIdentifier{Name: "envoy_server_downstream_cx_active"},
+					Target: v2.MetricTarget{Type: v2.AverageValueMetricType, AverageValue: &value},
+				},
+			},
+			{
+				Type: v2.ObjectMetricSourceType,
+				Object: &v2.ObjectMetricSource{
+					DescribedObject: v2.CrossVersionObjectReference{Kind: "Service", Name: "higress-gateway"},
+					Metric:          v2.MetricIdentifier{Name: "requests_per_second"},
+					Target:          v2.MetricTarget{Type: v2.ValueMetricType, Value: &value},
+				},
+			},
+		},
+	}))
+
+	res, err := convertV2ToV2beta1(hpa)
+	if err != nil {
+		t.Fatalf("convertV2ToV2beta1() error = %v", err)
+	}
+
+	if *res.Spec.MinReplicas != 2 || res.Spec.MaxReplicas != 5 {
+		t.Errorf("replicas = %d..%d, want 2..5", *res.Spec.MinReplicas, res.Spec.MaxReplicas)
+	}
+	if res.Spec.ScaleTargetRef.Kind != "Deployment" || res.Spec.ScaleTargetRef.Name != "higress-gateway" {
+		t.Errorf("scaleTargetRef = %+v", res.Spec.ScaleTargetRef)
+	}
+	if len(res.Spec.Metrics) != 3 {
+		t.Fatalf("got %d metrics, want 3", len(res.Spec.Metrics))
+	}
+
+	cpu := res.Spec.Metrics[0]
+	if cpu.Type != v2beta1.ResourceMetricSourceType || cpu.Resource.Name != apiv1.ResourceCPU ||
+		*cpu.Resource.TargetAverageUtilization != 80 {
+		t.Errorf("cpu metric = %+v", cpu.Resource)
+	}
+	pods := res.Spec.Metrics[1]
+	if pods.Pods.MetricName != "envoy_server_downstream_cx_active" || pods.Pods.TargetAverageValue.Cmp(value) != 0 {
+		t.Errorf("pods metric = %+v", pods.Pods)
+	}
+	object := res.Spec.Metrics[2]
+	if object.Object.Target.Name != "higress-gateway" || object.Object.TargetValue.Cmp(value) != 0 {
+		t.Errorf("object metric = %+v", object.Object)
+	}
+}
+
+func TestConvertV2ToV2beta1Errors(t *testing.T) {
+	value := resource.MustParse("100")
+	tests := []struct {
+		name        string
+		autoScaling *v1beta1.AutoScaling
+		want        string
+	}{
+		{
+			name: "behavior",
+			autoScaling: &v1beta1.AutoScaling{
+				Enable:      true,
+				MaxReplicas: 3,
+				Behavior: &v2.HorizontalPodAutoscalerBehavior{
+					ScaleDown: &v2.HPAScalingRules{StabilizationWindowSeconds: int32Ptr(300)},
+				},
+			},
+			want: "autoScaling.behavior requires autoscaling/v2beta2",
+		},
+		{
+			name: "pods metric without averageValue",
+			autoScaling: &v1beta1.AutoScaling{
+				Enable:      true,
+				MaxReplicas: 3,
+				Metrics: []v2.MetricSpec{{
+					Type: v2.PodsMetricSourceType,
+					Pods: &v2.PodsMetricSource{
+						Metric: v2.MetricIdentifier{Name: "connections"},
+						Target: v2.MetricTarget{Type: v2.ValueMetricType, Value: &value},
+					},
+				}},
+			},
+			want: `pods metric "connections" requires an averageValue target`,
+		},
+		{
+			name: "object metric without value",
+			autoScaling: &v1beta1.AutoScaling{
+				Enable:      true,
+				MaxReplicas: 3,
+				Metrics: []v2.MetricSpec{{
+					Type: v2.ObjectMetricSourceType,
+					Object: &v2.ObjectMetricSource{
+						Metric: v2.MetricIdentifier{Name: "requests_per_second"},
+						Target: v2.MetricTarget{Type: v2.AverageValueMetricType, AverageValue: &value},
+					},
+				}},
+			},
+			want: `object metric "requests_per_second" requires a value target`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hpa := initHPAv2(&v2.HorizontalPodAutoscaler{}, newAutoScalingGateway(tt.autoScaling))
+			if _, err := convertV2ToV2beta1(hpa); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("convertV2ToV2beta1() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertV2ToV2beta2(t *testing.T) {
+	selectPolicy := v2.MinChangePolicySelect
+	hpa := initHPAv2(&v2.HorizontalPodAutoscaler{}, newAutoScalingGateway(&v1beta1.AutoScaling{
+		Enable:                            true,
+		MaxReplicas:                       4,
+		TargetMemoryUtilizationPercentage: int32Ptr(70),
+		Behavior: &v2.HorizontalPodAutoscalerBehavior{
+			ScaleUp: &v2.HPAScalingRules{
+				SelectPolicy: &selectPolicy,
+				Policies:     []v2.HPAScalingPolicy{{Type: v2.PodsScalingPolicy, Value: 2, PeriodSeconds: 60}},
+			},
+		},
+	}))
+
+	res := convertV2ToV2beta2(hpa)
+
+	if len(res.Spec.Metrics) != 1 || res.Spec.Metrics[0].Resource.Name != apiv1.ResourceMemory ||
+		*res.Spec.Metrics[0].Resource.Target.AverageUtilization != 70 {
+		t.Errorf("metrics = %+v", res.Spec.Metrics)
+	}
+	scaleUp := res.Spec.Behavior.ScaleUp
+	if *scaleUp.SelectPolicy != v2beta2.MinPolicySelect || len(scaleUp.Policies) != 1 ||
+		scaleUp.Policies[0].Value != 2 || scaleUp.Policies[0].PeriodSeconds != 60 {
+		t.Errorf("scaleUp = %+v", scaleUp)
+	}
+	if res.Spec.Behavior.ScaleDown != nil {
+		t.Errorf("scaleDown = %+v, want nil", res.Spec.Behavior.ScaleDown)
+	}
+}
+
+func TestInitHPAVersions(t *testing.T) {
+	instance := newAutoScalingGateway(&v1beta1.AutoScaling{Enable: true, MaxReplicas: 2})
+	for version, want := range map[string]interface{}{
+		autoscalingV2:      &v2.HorizontalPodAutoscaler{},
+		autoscalingV2beta2: &v2beta2.HorizontalPodAutoscaler{},
+		autoscalingV2beta1: &v2beta1.HorizontalPodAutoscaler{},
+	} {
+		hpa, err := initHPA(version, instance)
+		if err != nil {
+			t.Fatalf("initHPA(%s) error = %v", version, err)
+		}
+		if got, want := fmt.Sprintf("%T", hpa), fmt.Sprintf("%T", want); got != want {
+			t.Errorf("initHPA(%s) = %s, want %s", version, got, want)
+		}
+		if got, want := fmt.Sprintf("%T", newHPA(version)), fmt.Sprintf("%T", want); got != want {
+			t.Errorf("newHPA(%s) = %s, want %s", version, got, want)
+		}
+	}
+}
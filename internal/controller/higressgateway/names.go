@@ -0,0 +1,98 @@
+package higressgateway
+
+import (
+	"fmt"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+	"github.com/alibaba/higress/higress-operator/internal/controller"
+)
+
+// The names of the objects of a gateway are derived from the name of its CR, so that several
+// gateways can run in one namespace. A CR named higress-gateway gets the names of the helm chart.
+// The instances of the initial defaults version keep the fixed names they were created with, as
+// renaming would replace their running objects.
+
+const (
+	legacyName                   = "higress-gateway"
+	legacySkywalkingConfigMap    = "higress-custom-bootstrap"
+	legacySelectorLabelNamespace = "higress-system-higress-gateway"
+
+	// instanceNamesDefaultsVersion is the first defaults version with the names derived from the CR.
+	instanceNamesDefaultsVersion = 2
+)
+
+// legacyNames returns true if instance keeps the fixed names of the initial defaults version.
+func legacyNames(instance *v1beta1.HigressGateway) bool {
+	return controller.DefaultsVersion(instance) < instanceNamesDefaultsVersion
+}
+
+func getServiceName(instance *v1beta1.HigressGateway) string {
+	if legacyNames(instance) {
+		return legacyName
+	}
+	return instance.Name
+}
+
+func getContainerName(instance *v1beta1.HigressGateway) string {
+	if legacyNames(instance) {
+		return legacyName
+	}
+	return instance.Name
+}
+
+func getRoleName(instance *v1beta1.HigressGateway) string {
+	if legacyNames(instance) {
+		return legacyName
+	}
+	return instance.Name
+}
+
+// getClusterRoleName returns the name of the ClusterRole and ClusterRoleBinding, which are shared
+// by the gateways of the same name in different namespaces.
+func getClusterRoleName(instance *v1beta1.HigressGateway) string {
+	if legacyNames(instance) {
+		return legacyName
+	}
+	return instance.Name
+}
+
+// getConfigMapName returns the name of the mesh config ConfigMap, the legacy name is the one that
+// the pods and the controller of the initial defaults version mount.
+func getConfigMapName(instance *v1beta1.HigressGateway) string {
+	if legacyNames(instance) {
+		return controller.HigressGatewayConfig
+	}
+	return controller.GatewayConfigName(instance.Name)
+}
+
+// getSkywalkingConfigMapName returns the name of the skywalking bootstrap ConfigMap, the legacy
+// name is the one that the pods of the initial defaults version mount.
+func getSkywalkingConfigMapName(instance *v1beta1.HigressGateway) string {
+	if legacyNames(instance) {
+		return legacySkywalkingConfigMap
+	}
+	return fmt.Sprintf("%s-custom-bootstrap", instance.Name)
+}
+
+// legacySelectorLabels returns the fixed selector of the initial defaults version.
+func legacySelectorLabels(instance *v1beta1.HigressGateway) map[string]string {
+	if instance.Spec.ControllerRef != nil {
+		return map[string]string{"app": legacyName}
+	}
+	return map[string]string{
+		"app":     legacyName,
+		"higress": legacySelectorLabelNamespace,
+	}
+}
+
+// defaultSelectorLabels leaves out the label that the controller selects the gateway by if the
+// gateway has a controllerRef, as its value is only known once the controller is looked up.
+func defaultSelectorLabels(instance *v1beta1.HigressGateway) map[string]string {
+	if instance.Spec.ControllerRef != nil {
+		return map[string]string{"app": instance.Name}
+	}
+	return map[string]string{
+		"app":     instance.Name,
+		"higress": fmt.Sprintf("%s-%s", instance.Namespace, instance.Name),
+	}
+}
@@ -5,14 +5,8 @@ import (
 
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	operatorv1alpha1 "github.com/alibaba/higress/higress-operator/api/v1alpha1"
-)
-
-const (
-	role        = "higress-gateway"
-	clusterRole = "higress-gateway"
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
 )
 
 func defaultRules() []rbacv1.PolicyRule {
@@ -27,38 +21,33 @@ func defaultRules() []rbacv1.PolicyRule {
 	return rules
 }
 
-func initClusterRole(cr *rbacv1.ClusterRole, _ *operatorv1alpha1.HigressGateway) *rbacv1.ClusterRole {
+func initClusterRole(cr *rbacv1.ClusterRole, instance *operatorv1beta1.HigressGateway) *rbacv1.ClusterRole {
 	*cr = rbacv1.ClusterRole{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: clusterRole,
+			Name: getClusterRoleName(instance),
 		},
 		Rules: defaultRules(),
 	}
 	return cr
 }
 
-func muteClusterRole(cr *rbacv1.ClusterRole, instance *operatorv1alpha1.HigressGateway) controllerutil.MutateFn {
-	return func() error {
-		cr.Rules = defaultRules()
-		return nil
-	}
-}
-
-func initClusterRoleBinding(crb *rbacv1.ClusterRoleBinding, instance *operatorv1alpha1.HigressGateway) *rbacv1.ClusterRoleBinding {
+func initClusterRoleBinding(crb *rbacv1.ClusterRoleBinding, instance *operatorv1beta1.HigressGateway) *rbacv1.ClusterRoleBinding {
+	// the binding is shared by the gateways of the same name, so the subjects of the others are kept
 	*crb = rbacv1.ClusterRoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: getServiceAccount(instance),
+			Name: getClusterRoleName(instance),
 		},
+		Subjects: crb.Subjects,
 	}
 
 	updateClusterRoleBinding(crb, instance)
 	return crb
 }
 
-func updateClusterRoleBinding(crb *rbacv1.ClusterRoleBinding, instance *operatorv1alpha1.HigressGateway) {
+func updateClusterRoleBinding(crb *rbacv1.ClusterRoleBinding, instance *operatorv1beta1.HigressGateway) {
 	crb.RoleRef = rbacv1.RoleRef{
 		Kind:     "ClusterRole",
-		Name:     clusterRole,
+		Name:     getClusterRoleName(instance),
 		APIGroup: "rbac.authorization.k8s.io",
 	}
 
@@ -77,22 +66,15 @@ func updateClusterRoleBinding(crb *rbacv1.ClusterRoleBinding, instance *operator
 	crb.Subjects = append(crb.Subjects, subject)
 }
 
-func muteClusterRoleBinding(crb *rbacv1.ClusterRoleBinding, instance *operatorv1alpha1.HigressGateway) controllerutil.MutateFn {
-	return func() error {
-		updateClusterRoleBinding(crb, instance)
-		return nil
-	}
-}
-
-func initRoleBinding(rb *rbacv1.RoleBinding, instance *operatorv1alpha1.HigressGateway) *rbacv1.RoleBinding {
+func initRoleBinding(rb *rbacv1.RoleBinding, instance *operatorv1beta1.HigressGateway) *rbacv1.RoleBinding {
 	*rb = rbacv1.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      role,
+			Name:      getRoleName(instance),
 			Namespace: instance.Namespace,
 		},
 		RoleRef: rbacv1.RoleRef{
 			Kind:     "Role",
-			Name:     getServiceAccount(instance),
+			Name:     getRoleName(instance),
 			APIGroup: "rbac.authorization.k8s.io",
 		},
 		Subjects: []rbacv1.Subject{
@@ -106,39 +88,10 @@ func initRoleBinding(rb *rbacv1.RoleBinding, instance *operatorv1alpha1.HigressG
 	return rb
 }
 
-func updateRoleBinding(rb *rbacv1.RoleBinding, instance *operatorv1alpha1.HigressGateway) {
-	rb.RoleRef = rbacv1.RoleRef{
-		Kind:     "Role",
-		Name:     role,
-		APIGroup: "rbac.authorization.k8s.io",
-	}
-
-	subject := rbacv1.Subject{
-		Kind:      "ServiceAccount",
-		Name:      getServiceAccount(instance),
-		Namespace: instance.Namespace,
-	}
-
-	for _, sub := range rb.Subjects {
-		if reflect.DeepEqual(sub, subject) {
-			return
-		}
-	}
-
-	rb.Subjects = append(rb.Subjects, subject)
-}
-
-func muteRoleBinding(rb *rbacv1.RoleBinding, instance *operatorv1alpha1.HigressGateway) controllerutil.MutateFn {
-	return func() error {
-		updateRoleBinding(rb, instance)
-		return nil
-	}
-}
-
-func initRole(r *rbacv1.Role, instance *operatorv1alpha1.HigressGateway) *rbacv1.Role {
+func initRole(r *rbacv1.Role, instance *operatorv1beta1.HigressGateway) *rbacv1.Role {
 	*r = rbacv1.Role{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      role,
+			Name:      getRoleName(instance),
 			Namespace: instance.Namespace,
 		},
 		Rules: defaultRules(),
@@ -146,10 +99,3 @@ func initRole(r *rbacv1.Role, instance *operatorv1alpha1.HigressGateway) *rbacv1
 
 	return r
 }
-
-func muteRole(role *rbacv1.Role, instance *operatorv1alpha1.HigressGateway) controllerutil.MutateFn {
-	return func() error {
-		role.Rules = defaultRules()
-		return nil
-	}
-}
@@ -4,22 +4,18 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	"github.com/alibaba/higress/higress-operator/api/v1alpha1"
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+	"github.com/alibaba/higress/higress-operator/internal/controller"
 )
 
-const (
-	HigressGatewayServiceName = "higress-gateway"
-)
-
-func initService(svc *apiv1.Service, instance *v1alpha1.HigressGateway) *apiv1.Service {
+func initService(svc *apiv1.Service, instance *v1beta1.HigressGateway) *apiv1.Service {
 	*svc = apiv1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        HigressGatewayServiceName,
+			Name:        getServiceName(instance),
 			Namespace:   instance.Namespace,
 			Labels:      instance.Labels,
-			Annotations: instance.Annotations,
+			Annotations: controller.ObjectAnnotations(instance),
 		},
 	}
 
@@ -27,11 +23,11 @@ func initService(svc *apiv1.Service, instance *v1alpha1.HigressGateway) *apiv1.S
 	return svc
 }
 
-func updateServiceSpec(svc *apiv1.Service, instance *v1alpha1.HigressGateway) {
+func updateServiceSpec(svc *apiv1.Service, instance *v1beta1.HigressGateway) {
 	svc.Spec.Selector = instance.Spec.SelectorLabels
 
-	if instance.Spec.NetWorkGateway != "" {
-		svc.ObjectMeta.Labels["topology.istio.io/network"] = instance.Spec.NetWorkGateway
+	if instance.Spec.NetworkGateway != "" {
+		svc.ObjectMeta.Labels["topology.istio.io/network"] = instance.Spec.NetworkGateway
 	}
 
 	if instance.Spec.Service != nil {
@@ -49,7 +45,7 @@ func updateServiceSpec(svc *apiv1.Service, instance *v1alpha1.HigressGateway) {
 		svc.Spec.Ports = instance.Spec.Service.Ports
 	}
 
-	if instance.Spec.NetWorkGateway != "" {
+	if instance.Spec.NetworkGateway != "" {
 		svc.Spec.Ports = []apiv1.ServicePort{
 			{
 				Name:       "status-port",
@@ -74,10 +70,3 @@ func updateServiceSpec(svc *apiv1.Service, instance *v1alpha1.HigressGateway) {
 		}
 	}
 }
-
-func muteService(svc *apiv1.Service, instance *v1alpha1.HigressGateway) controllerutil.MutateFn {
-	return func() error {
-		updateServiceSpec(svc, instance)
-		return nil
-	}
-}
@@ -4,10 +4,10 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"github.com/alibaba/higress/higress-operator/api/v1alpha1"
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
 )
 
-func initServiceAccount(sa *apiv1.ServiceAccount, instance *v1alpha1.HigressGateway) *apiv1.ServiceAccount {
+func initServiceAccount(sa *apiv1.ServiceAccount, instance *v1beta1.HigressGateway) *apiv1.ServiceAccount {
 	*sa = apiv1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace:   instance.Namespace,
@@ -20,7 +20,7 @@ func initServiceAccount(sa *apiv1.ServiceAccount, instance *v1alpha1.HigressGate
 	return sa
 }
 
-func getServiceAccount(instance *v1alpha1.HigressGateway) string {
+func getServiceAccount(instance *v1beta1.HigressGateway) string {
 	if instance.Spec.ServiceAccount.Name != "" {
 		return instance.Spec.ServiceAccount.Name
 	}
@@ -0,0 +1,194 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	apiv1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+// Labels set on the objects applied by the operator. Owner references can't point from
+// cluster-scoped objects to namespaced instances, so the owner is recorded in labels instead.
+// Cluster-scoped objects may be shared by several instances and only get LabelManagedBy.
+const (
+	LabelManagedBy      = "app.kubernetes.io/managed-by"
+	LabelOwnerKind      = "operator.higress.io/owner-kind"
+	LabelOwnerNamespace = "operator.higress.io/owner-namespace"
+	LabelOwnerName      = "operator.higress.io/owner-name"
+
+	// ManagedByOperator is the value of LabelManagedBy on the objects created by the operator.
+	ManagedByOperator = "higress-operator"
+)
+
+func setInventoryLabels(cli client.Client, obj *unstructured.Unstructured, owner client.Object) error {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	labels[LabelManagedBy] = ManagedByOperator
+	if owner != nil && obj.GetNamespace() != "" {
+		gvk, err := apiutil.GVKForObject(owner, cli.Scheme())
+		if err != nil {
+			return err
+		}
+		labels[LabelOwnerKind] = gvk.Kind
+		labels[LabelOwnerNamespace] = owner.GetNamespace()
+		labels[LabelOwnerName] = owner.GetName()
+	}
+	obj.SetLabels(labels)
+	return nil
+}
+
+func isOwnedBy(cli client.Client, obj *unstructured.Unstructured, owner client.Object) (bool, error) {
+	gvk, err := apiutil.GVKForObject(owner, cli.Scheme())
+	if err != nil {
+		return false, err
+	}
+
+	labels := obj.GetLabels()
+	return labels[LabelOwnerKind] == gvk.Kind &&
+		labels[LabelOwnerNamespace] == owner.GetNamespace() &&
+		labels[LabelOwnerName] == owner.GetName(), nil
+}
+
+func refOf(obj *unstructured.Unstructured) v1beta1.ResourceRef {
+	return v1beta1.ResourceRef{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}
+}
+
+// Inventory returns the objects applied in ctx, sorted so that the status doesn't change between
+// reconciles.
+func Inventory(ctx context.Context) []v1beta1.ResourceRef {
+	seen := map[v1beta1.ResourceRef]struct{}{}
+	var inventory []v1beta1.ResourceRef
+	for _, ref := range getApplyState(ctx).applied {
+		if _, ok := seen[ref]; !ok {
+			seen[ref] = struct{}{}
+			inventory = append(inventory, ref)
+		}
+	}
+
+	sort.Slice(inventory, func(i, j int) bool {
+		return fmt.Sprint(inventory[i]) < fmt.Sprint(inventory[j])
+	})
+	return inventory
+}
+
+// Prune deletes the objects of the previous inventory of the owner in ctx that were not applied
+// in ctx. Namespaced objects are only deleted if their labels still point to the owner. The
+// shared cluster-scoped RBAC objects are only deleted once no other instance uses them.
+func Prune(ctx context.Context, cli client.Client, previous []v1beta1.ResourceRef, logger logr.Logger) error {
+	state := getApplyState(ctx)
+	if state.Owner == nil {
+		return nil
+	}
+
+	applied := map[v1beta1.ResourceRef]struct{}{}
+	for _, ref := range state.applied {
+		applied[ref] = struct{}{}
+	}
+
+	// ClusterRoles go last, as they are only deleted once the bindings to them are gone
+	previous = append([]v1beta1.ResourceRef(nil), previous...)
+	sort.SliceStable(previous, func(i, j int) bool {
+		return previous[i].Kind != "ClusterRole" && previous[j].Kind == "ClusterRole"
+	})
+
+	for _, ref := range previous {
+		if _, ok := applied[ref]; ok {
+			continue
+		}
+
+		if err := prune(ctx, cli, state, ref, logger); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to prune object {%s:%s/%s}", ref.Kind, ref.Namespace, ref.Name))
+			return err
+		}
+	}
+
+	return nil
+}
+
+func prune(ctx context.Context, cli client.Client, state *applyState, ref v1beta1.ResourceRef, logger logr.Logger) error {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind))
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if obj.GetLabels()[LabelManagedBy] != ManagedByOperator {
+		return nil
+	}
+
+	key := client.ObjectKeyFromObject(obj)
+	switch {
+	case ref.Namespace != "":
+		if owned, err := isOwnedBy(cli, obj, state.Owner); err != nil || !owned {
+			return err
+		}
+	case ref.Kind == "ClusterRoleBinding":
+		crb := &rbacv1.ClusterRoleBinding{}
+		if err := cli.Get(ctx, key, crb); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if remaining := RemoveSubjects(crb, state.Owner.GetNamespace()); len(remaining) > 0 {
+			crb.Subjects = remaining
+			if err := cli.Update(ctx, crb); err != nil {
+				return err
+			}
+			logger.Info(fmt.Sprintf("remove the subjects of namespace %s from ClusterRoleBinding %s", state.Owner.GetNamespace(), key.Name))
+			return nil
+		}
+	case ref.Kind == "ClusterRole":
+		if used, err := IsClusterRoleBound(ctx, cli, ref.Name); err != nil || used {
+			return err
+		}
+	}
+
+	if err := cli.Delete(ctx, obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	logger.Info(fmt.Sprintf("prune object {%s:%s}", ref.Kind, key))
+	state.event(apiv1.EventTypeNormal, ReasonDeleted, "Deleted %s %s", ref.Kind, key)
+	return nil
+}
+
+// RemoveSubjects returns the subjects of crb other than the service accounts in namespace.
+func RemoveSubjects(crb *rbacv1.ClusterRoleBinding, namespace string) []rbacv1.Subject {
+	var subjects []rbacv1.Subject
+	for _, subject := range crb.Subjects {
+		if subject.Kind != rbacv1.ServiceAccountKind || subject.Namespace != namespace {
+			subjects = append(subjects, subject)
+		}
+	}
+	return subjects
+}
+
+// IsClusterRoleBound returns true if any ClusterRoleBinding refers to the ClusterRole name.
+func IsClusterRoleBound(ctx context.Context, cli client.Client, name string) (bool, error) {
+	crbs := &rbacv1.ClusterRoleBindingList{}
+	if err := cli.List(ctx, crbs); err != nil {
+		return false, err
+	}
+
+	for _, crb := range crbs.Items {
+		if crb.RoleRef.Kind == "ClusterRole" && crb.RoleRef.Name == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
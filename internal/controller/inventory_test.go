@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	apiv1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+func configMapRef(name string) v1beta1.ResourceRef {
+	return v1beta1.ResourceRef{APIVersion: "v1", Kind: "ConfigMap", Namespace: "tenant", Name: name}
+}
+
+func clusterRef(kind, name string) v1beta1.ResourceRef {
+	return v1beta1.ResourceRef{APIVersion: "rbac.authorization.k8s.io/v1", Kind: kind, Name: name}
+}
+
+func TestPrune(t *testing.T) {
+	managed := map[string]string{LabelManagedBy: ManagedByOperator}
+	cli := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(
+		newConfigMap("applied", managedLabels("edge")),
+		newConfigMap("stale", managedLabels("edge")),
+		newConfigMap("other-owner", managedLabels("other")),
+		newConfigMap("unmanaged", nil),
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "bound", Labels: managed}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "unbound", Labels: managed}},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared", Labels: managed},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "bound"},
+			Subjects: []rbacv1.Subject{
+				{Kind: rbacv1.ServiceAccountKind, Namespace: "tenant", Name: "edge"},
+				{Kind: rbacv1.ServiceAccountKind, Namespace: "other", Name: "edge"},
+			},
+		},
+	).Build()
+
+	ctx := WithApplyOptions(context.Background(), ApplyOptions{Owner: newOwner()})
+	getApplyState(ctx).applied = []v1beta1.ResourceRef{configMapRef("applied")}
+	previous := []v1beta1.ResourceRef{
+		clusterRef("ClusterRole", "unbound"),
+		clusterRef("ClusterRole", "bound"),
+		configMapRef("applied"),
+		configMapRef("stale"),
+		configMapRef("other-owner"),
+		configMapRef("unmanaged"),
+		configMapRef("missing"),
+		clusterRef("ClusterRoleBinding", "shared"),
+	}
+	if err := Prune(ctx, cli, previous, logr.Discard()); err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+
+	for _, tt := range []struct {
+		obj     client.Object
+		name    string
+		deleted bool
+	}{
+		{obj: &apiv1.ConfigMap{}, name: "applied"},
+		{obj: &apiv1.ConfigMap{}, name: "stale", deleted: true},
+		{obj: &apiv1.ConfigMap{}, name: "other-owner"},
+		{obj: &apiv1.ConfigMap{}, name: "unmanaged"},
+		{obj: &rbacv1.ClusterRole{}, name: "bound"},
+		{obj: &rbacv1.ClusterRole{}, name: "unbound", deleted: true},
+	} {
+		key := types.NamespacedName{Name: tt.name}
+		if _, ok := tt.obj.(*apiv1.ConfigMap); ok {
+			key.Namespace = "tenant"
+		}
+		err := cli.Get(ctx, key, tt.obj)
+		if tt.deleted != errors.IsNotFound(err) || (!tt.deleted && err != nil) {
+			t.Errorf("%T %s: error = %v, want deleted %v", tt.obj, tt.name, err, tt.deleted)
+		}
+	}
+
+	// the binding is shared with another namespace, which keeps its subjects
+	crb := &rbacv1.ClusterRoleBinding{}
+	if err := cli.Get(ctx, types.NamespacedName{Name: "shared"}, crb); err != nil {
+		t.Fatalf("ClusterRoleBinding shared: error = %v", err)
+	}
+	if len(crb.Subjects) != 1 || crb.Subjects[0].Namespace != "other" {
+		t.Errorf("subjects = %v, want only the ones of namespace other", crb.Subjects)
+	}
+}
+
+func TestPruneWithoutOwner(t *testing.T) {
+	cli := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(newConfigMap("stale", managedLabels("edge"))).Build()
+
+	if err := Prune(context.Background(), cli, []v1beta1.ResourceRef{configMapRef("stale")}, logr.Discard()); err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if err := cli.Get(context.Background(), types.NamespacedName{Namespace: "tenant", Name: "stale"}, &apiv1.ConfigMap{}); err != nil {
+		t.Errorf("error = %v, want the object kept without an owner in the context", err)
+	}
+}
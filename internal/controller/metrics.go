@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+// Steps of a reconcile, used as the step label of the reconcile metrics.
+const (
+	StepCRDs           = "CRDs"
+	StepGatewayAPI     = "GatewayAPI"
+	StepControllerRef  = "ControllerRef"
+	StepIngressClass   = "IngressClass"
+	StepServiceAccount = "ServiceAccount"
+	StepRBAC           = "RBAC"
+	StepConfigMap      = "ConfigMap"
+	StepSecret         = "Secret"
+	StepDeployment     = "Deployment"
+	StepService        = "Service"
+	StepAutoScaling    = "AutoScaling"
+	StepDisruption     = "PodDisruptionBudget"
+	StepClasses        = "Classes"
+	StepController     = "HigressController"
+	StepGateway        = "HigressGateway"
+	StepConsole        = "HigressConsole"
+	StepPrune          = "Prune"
+)
+
+var (
+	// ReconcileStepDuration observes the duration of every step of a reconcile.
+	ReconcileStepDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "higress_operator_reconcile_step_duration_seconds",
+		Help:    "Duration of the steps of a reconcile.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"kind", "step"})
+
+	// ReconcileStepErrors counts the failed steps of the reconciles.
+	ReconcileStepErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "higress_operator_reconcile_step_errors_total",
+		Help: "Number of failed steps of the reconciles.",
+	}, []string{"kind", "step"})
+
+	// DriftTotal counts the objects found to have drifted from the spec of their CR.
+	DriftTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "higress_operator_drift_total",
+		Help: "Number of times a managed object was found to have drifted from the spec of its CR.",
+	}, []string{"kind", "namespace", "name", "policy"})
+
+	// FieldConflictTotal counts the applies that conflicted with fields owned by other field managers.
+	FieldConflictTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "higress_operator_field_conflicts_total",
+		Help: "Number of applies of a managed object that conflicted with the fields of other field managers.",
+	}, []string{"kind", "namespace", "name"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(ReconcileStepDuration, ReconcileStepErrors, DriftTotal, FieldConflictTotal)
+}
+
+// ObserveStep runs a step of the reconcile of kind and records its duration and failure.
+func ObserveStep(kind, step string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	ReconcileStepDuration.WithLabelValues(kind, step).Observe(time.Since(start).Seconds())
+	if err != nil {
+		ReconcileStepErrors.WithLabelValues(kind, step).Inc()
+	}
+	return err
+}
+
+var (
+	instancesDesc = prometheus.NewDesc("higress_operator_managed_instances",
+		"Number of instances managed by the operator.", []string{"kind", "namespace"}, nil)
+	instanceImageDesc = prometheus.NewDesc("higress_operator_instance_image_info",
+		"Image of the containers of an instance, the value is always 1.",
+		[]string{"kind", "namespace", "name", "container", "image"}, nil)
+)
+
+// instanceCollector reports the instances and their images when the metrics are scraped.
+type instanceCollector struct {
+	reader client.Reader
+}
+
+// NewInstanceCollector returns a collector of the Higress, HigressGateway, HigressController and
+// HigressConsole instances read from reader, which should be the cache of the manager.
+func NewInstanceCollector(reader client.Reader) prometheus.Collector {
+	return &instanceCollector{reader: reader}
+}
+
+func (c *instanceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- instancesDesc
+	ch <- instanceImageDesc
+}
+
+func (c *instanceCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stacks := &v1beta1.HigressList{}
+	if err := c.reader.List(ctx, stacks); err == nil {
+		counts := map[string]float64{}
+		for _, stack := range stacks.Items {
+			counts[stack.Namespace]++
+		}
+		for namespace, count := range counts {
+			ch <- prometheus.MustNewConstMetric(instancesDesc, prometheus.GaugeValue, count, "Higress", namespace)
+		}
+	}
+
+	gateways := &v1beta1.HigressGatewayList{}
+	if err := c.reader.List(ctx, gateways); err == nil {
+		counts := map[string]float64{}
+		for _, gateway := range gateways.Items {
+			counts[gateway.Namespace]++
+			ch <- prometheus.MustNewConstMetric(instanceImageDesc, prometheus.GaugeValue, 1,
+				"HigressGateway", gateway.Namespace, gateway.Name, gateway.Name, imageOf(gateway.Spec.Image))
+		}
+		for namespace, count := range counts {
+			ch <- prometheus.MustNewConstMetric(instancesDesc, prometheus.GaugeValue, count, "HigressGateway", namespace)
+		}
+	}
+
+	controllers := &v1beta1.HigressControllerList{}
+	if err := c.reader.List(ctx, controllers); err == nil {
+		counts := map[string]float64{}
+		for _, controller := range controllers.Items {
+			counts[controller.Namespace]++
+			ch <- prometheus.MustNewConstMetric(instanceImageDesc, prometheus.GaugeValue, 1,
+				"HigressController", controller.Namespace, controller.Name, "controller", imageOf(controller.Spec.Controller.Image))
+			if !controller.Spec.EnableHigressIstio {
+				ch <- prometheus.MustNewConstMetric(instanceImageDesc, prometheus.GaugeValue, 1,
+					"HigressController", controller.Namespace, controller.Name, "pilot", imageOf(controller.Spec.Pilot.Image))
+			}
+		}
+		for namespace, count := range counts {
+			ch <- prometheus.MustNewConstMetric(instancesDesc, prometheus.GaugeValue, count, "HigressController", namespace)
+		}
+	}
+
+	consoles := &v1beta1.HigressConsoleList{}
+	if err := c.reader.List(ctx, consoles); err == nil {
+		counts := map[string]float64{}
+		for _, console := range consoles.Items {
+			counts[console.Namespace]++
+			ch <- prometheus.MustNewConstMetric(instanceImageDesc, prometheus.GaugeValue, 1,
+				"HigressConsole", console.Namespace, console.Name, "console", imageOf(console.Spec.Image))
+		}
+		for namespace, count := range counts {
+			ch <- prometheus.MustNewConstMetric(instancesDesc, prometheus.GaugeValue, count, "HigressConsole", namespace)
+		}
+	}
+}
+
+func imageOf(image v1beta1.Image) string {
+	return ImageReference(image.Repository, image.Tag)
+}
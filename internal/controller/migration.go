@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	apixv1client "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+// StorageMigrationInterval is the interval of the attempts to migrate the stored objects.
+const StorageMigrationInterval = time.Minute
+
+// StorageMigrationFieldManager is the field manager of the patches that migrate the objects. The
+// webhooks neither default nor validate these patches, as they don't change the objects.
+const StorageMigrationFieldManager = "higress-operator-storage-migration"
+
+// StorageMigration rewrites the stored objects of the CRDs of the operator in the storage version
+// v1beta1, and then drops the older versions from the stored versions of the CRDs, so that they can
+// be removed from the CRDs in a later release. It runs once the manager is elected as the leader
+// and retries until all objects are migrated.
+type StorageMigration struct {
+	// Client patches the objects, the conversion webhook of the manager has to be served.
+	Client client.Client
+	// Reader lists the objects without caching them.
+	Reader client.Reader
+	Config *rest.Config
+}
+
+// storageMigrations are the CRDs of the operator with the lists of their objects.
+var storageMigrations = []struct {
+	crd  string
+	list func() client.ObjectList
+}{
+	{"higressgateways.operator.higress.io", func() client.ObjectList { return &v1beta1.HigressGatewayList{} }},
+	{"higresscontrollers.operator.higress.io", func() client.ObjectList { return &v1beta1.HigressControllerList{} }},
+}
+
+//+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions/status,verbs=update
+
+func (m *StorageMigration) NeedLeaderElection() bool {
+	return true
+}
+
+func (m *StorageMigration) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("storage-migration")
+
+	apixClient, err := apixv1client.NewForConfig(m.Config)
+	if err != nil {
+		return err
+	}
+
+	// a failed migration must not stop the manager, the objects are served in both versions anyway
+	_ = wait.PollUntilContextCancel(ctx, StorageMigrationInterval, true, func(ctx context.Context) (bool, error) {
+		done := true
+		for _, migration := range storageMigrations {
+			if err := m.migrate(ctx, apixClient.CustomResourceDefinitions(), migration.crd, migration.list(), logger); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to migrate the objects of CRD %s", migration.crd))
+				done = false
+			}
+		}
+		return done, nil
+	})
+	return nil
+}
+
+// migrate rewrites the objects of the CRD crd, which are listed by list, unless the CRD only has
+// objects stored in its storage version.
+func (m *StorageMigration) migrate(ctx context.Context, cli apixv1client.CustomResourceDefinitionInterface, crd string,
+	list client.ObjectList, logger logr.Logger) error {
+	definition, err := cli.Get(ctx, crd, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	var storageVersion string
+	for _, v := range definition.Spec.Versions {
+		if v.Storage {
+			storageVersion = v.Name
+		}
+	}
+	if len(definition.Status.StoredVersions) == 1 && definition.Status.StoredVersions[0] == storageVersion {
+		return nil
+	}
+
+	if err = m.Reader.List(ctx, list); err != nil {
+		return err
+	}
+	objects, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+
+	// an empty patch doesn't change an object but stores it in the storage version. The objects
+	// that fail are retried with the next attempt, after the others are migrated.
+	var errs []error
+	for _, obj := range objects {
+		object := obj.(client.Object)
+		err = m.Client.Patch(ctx, object, client.RawPatch(types.MergePatchType, []byte("{}")),
+			client.FieldOwner(StorageMigrationFieldManager))
+		if err != nil && !errors.IsNotFound(err) {
+			logger.Error(err, fmt.Sprintf("Failed to rewrite %s/%s of CRD %s", object.GetNamespace(), object.GetName(), crd))
+			errs = append(errs, fmt.Errorf("failed to rewrite %s/%s: %w", object.GetNamespace(), object.GetName(), err))
+		}
+	}
+	if len(errs) > 0 {
+		return utilerrors.NewAggregate(errs)
+	}
+	logger.Info(fmt.Sprintf("rewrite %d objects of CRD %s in version %s", len(objects), crd, storageVersion))
+
+	definition.Status.StoredVersions = []string{storageVersion}
+	_, err = cli.UpdateStatus(ctx, definition, metav1.UpdateOptions{})
+	return err
+}
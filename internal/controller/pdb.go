@@ -0,0 +1,80 @@
+package controller
+
+import (
+	policyv1 "k8s.io/api/policy/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+const (
+	policyV1      = "v1"
+	policyV1beta1 = "v1beta1"
+)
+
+// GetPDBVersion returns the newest policy API version served by the cluster, policy/v1beta1 is
+// only served by clusters older than 1.21.
+func GetPDBVersion(cfg *rest.Config) (string, error) {
+	return ServerPreferredVersion(cfg, "policy", policyV1, policyV1beta1)
+}
+
+// PDBEnabled returns true if a PodDisruptionBudget is managed for the pods of the workload.
+func PDBEnabled(spec *v1beta1.CRDCommonFields) bool {
+	return spec.PodDisruptionBudget != nil && spec.PodDisruptionBudget.Enable
+}
+
+// NewPDB returns an empty PodDisruptionBudget of the given policy API version.
+func NewPDB(version string) client.Object {
+	if version == policyV1beta1 {
+		return &policyv1beta1.PodDisruptionBudget{}
+	}
+	return &policyv1.PodDisruptionBudget{}
+}
+
+// InitPDB renders the PodDisruptionBudget of the pods selected by the selectorLabels of spec in
+// the given policy API version. The budget has the name, namespace and labels of meta. If neither
+// minAvailable nor maxUnavailable is set, one pod may be evicted at a time, so that the budget
+// doesn't block the drains of the nodes of a single replica.
+func InitPDB(version string, meta metav1.ObjectMeta, spec *v1beta1.CRDCommonFields) client.Object {
+	budget := spec.PodDisruptionBudget
+	minAvailable, maxUnavailable := budget.MinAvailable, budget.MaxUnavailable
+	if minAvailable == nil && maxUnavailable == nil {
+		one := intstr.FromInt(1)
+		maxUnavailable = &one
+	}
+	selector := &metav1.LabelSelector{MatchLabels: spec.SelectorLabels}
+
+	if version == policyV1beta1 {
+		pdb := &policyv1beta1.PodDisruptionBudget{
+			ObjectMeta: meta,
+			Spec: policyv1beta1.PodDisruptionBudgetSpec{
+				MinAvailable:   minAvailable,
+				MaxUnavailable: maxUnavailable,
+				Selector:       selector,
+			},
+		}
+		if budget.UnhealthyPodEvictionPolicy != "" {
+			policy := policyv1beta1.UnhealthyPodEvictionPolicyType(budget.UnhealthyPodEvictionPolicy)
+			pdb.Spec.UnhealthyPodEvictionPolicy = &policy
+		}
+		return pdb
+	}
+
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: meta,
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable:   minAvailable,
+			MaxUnavailable: maxUnavailable,
+			Selector:       selector,
+		},
+	}
+	if budget.UnhealthyPodEvictionPolicy != "" {
+		policy := policyv1.UnhealthyPodEvictionPolicyType(budget.UnhealthyPodEvictionPolicy)
+		pdb.Spec.UnhealthyPodEvictionPolicy = &policy
+	}
+	return pdb
+}
@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	policyv1 "k8s.io/api/policy/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+func TestInitPDB(t *testing.T) {
+	one, half := intstr.FromInt(1), intstr.FromString("50%")
+	tests := []struct {
+		name               string
+		budget             v1beta1.PodDisruptionBudget
+		wantMinAvailable   *intstr.IntOrString
+		wantMaxUnavailable *intstr.IntOrString
+	}{
+		{
+			name:               "default",
+			budget:             v1beta1.PodDisruptionBudget{Enable: true},
+			wantMaxUnavailable: &one,
+		},
+		{
+			name:             "minAvailable",
+			budget:           v1beta1.PodDisruptionBudget{Enable: true, MinAvailable: &half},
+			wantMinAvailable: &half,
+		},
+		{
+			name:               "maxUnavailable",
+			budget:             v1beta1.PodDisruptionBudget{Enable: true, MaxUnavailable: &half},
+			wantMaxUnavailable: &half,
+		},
+	}
+
+	meta := metav1.ObjectMeta{Name: "edge", Namespace: "tenant", Labels: map[string]string{"team": "edge"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &v1beta1.CRDCommonFields{
+				SelectorLabels:      map[string]string{"app": "edge"},
+				PodDisruptionBudget: &tt.budget,
+			}
+
+			pdb, ok := InitPDB(policyV1, meta, spec).(*policyv1.PodDisruptionBudget)
+			if !ok {
+				t.Fatalf("InitPDB(%s) is not a policy/v1 PodDisruptionBudget", policyV1)
+			}
+			if !reflect.DeepEqual(pdb.ObjectMeta, meta) {
+				t.Errorf("metadata = %+v, want %+v", pdb.ObjectMeta, meta)
+			}
+			if !reflect.DeepEqual(pdb.Spec.Selector.MatchLabels, spec.SelectorLabels) {
+				t.Errorf("selector = %v, want %v", pdb.Spec.Selector.MatchLabels, spec.SelectorLabels)
+			}
+			if !reflect.DeepEqual(pdb.Spec.MinAvailable, tt.wantMinAvailable) ||
+				!reflect.DeepEqual(pdb.Spec.MaxUnavailable, tt.wantMaxUnavailable) {
+				t.Errorf("minAvailable, maxUnavailable = %v, %v, want %v, %v", pdb.Spec.MinAvailable,
+					pdb.Spec.MaxUnavailable, tt.wantMinAvailable, tt.wantMaxUnavailable)
+			}
+
+			legacy, ok := InitPDB(policyV1beta1, meta, spec).(*policyv1beta1.PodDisruptionBudget)
+			if !ok {
+				t.Fatalf("InitPDB(%s) is not a policy/v1beta1 PodDisruptionBudget", policyV1beta1)
+			}
+			if !reflect.DeepEqual(legacy.Spec.MinAvailable, tt.wantMinAvailable) ||
+				!reflect.DeepEqual(legacy.Spec.MaxUnavailable, tt.wantMaxUnavailable) {
+				t.Errorf("policy/v1beta1 minAvailable, maxUnavailable = %v, %v, want %v, %v", legacy.Spec.MinAvailable,
+					legacy.Spec.MaxUnavailable, tt.wantMinAvailable, tt.wantMaxUnavailable)
+			}
+		})
+	}
+}
+
+func TestInitPDBUnhealthyPodEvictionPolicy(t *testing.T) {
+	spec := &v1beta1.CRDCommonFields{PodDisruptionBudget: &v1beta1.PodDisruptionBudget{
+		Enable:                     true,
+		UnhealthyPodEvictionPolicy: string(policyv1.AlwaysAllow),
+	}}
+
+	pdb := InitPDB(policyV1, metav1.ObjectMeta{}, spec).(*policyv1.PodDisruptionBudget)
+	if policy := pdb.Spec.UnhealthyPodEvictionPolicy; policy == nil || *policy != policyv1.AlwaysAllow {
+		t.Errorf("unhealthyPodEvictionPolicy = %v, want %s", policy, policyv1.AlwaysAllow)
+	}
+
+	pdb = InitPDB(policyV1, metav1.ObjectMeta{}, &v1beta1.CRDCommonFields{
+		PodDisruptionBudget: &v1beta1.PodDisruptionBudget{Enable: true},
+	}).(*policyv1.PodDisruptionBudget)
+	if policy := pdb.Spec.UnhealthyPodEvictionPolicy; policy != nil {
+		t.Errorf("unhealthyPodEvictionPolicy = %v, want the policy of the cluster", *policy)
+	}
+}
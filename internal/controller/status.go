@@ -0,0 +1,266 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+// Reasons of the conditions set by the reconcilers.
+const (
+	ReasonReconcileSucceeded = "ReconcileSucceeded"
+
+	ReasonServiceAccountFailed = "ServiceAccountFailed"
+	ReasonRBACFailed           = "RBACFailed"
+	ReasonConfigMapFailed      = "ConfigMapFailed"
+	ReasonSecretFailed         = "SecretFailed"
+	ReasonDeploymentFailed     = "DeploymentFailed"
+	ReasonServiceFailed        = "ServiceFailed"
+	ReasonAutoScalingFailed    = "AutoScalingFailed"
+	ReasonDisruptionFailed     = "PodDisruptionBudgetFailed"
+	ReasonCRDsFailed           = "CRDsFailed"
+	ReasonGatewayAPIFailed     = "GatewayAPIFailed"
+	ReasonPruneFailed          = "PruneFailed"
+	ReasonControllerRefFailed  = "ControllerRefFailed"
+	ReasonIngressClassConflict = "IngressClassConflict"
+	ReasonClassesFailed        = "ClassesFailed"
+	ReasonControllerFailed     = "HigressControllerFailed"
+	ReasonGatewayFailed        = "HigressGatewayFailed"
+	ReasonConsoleFailed        = "HigressConsoleFailed"
+
+	ReasonDeploymentNotFound       = "DeploymentNotFound"
+	ReasonDeploymentAvailable      = "DeploymentAvailable"
+	ReasonDeploymentUnavailable    = "DeploymentUnavailable"
+	ReasonRollingOut               = "RollingOut"
+	ReasonRolloutComplete          = "RolloutComplete"
+	ReasonProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+	ReasonReplicaFailure           = "ReplicaFailure"
+	ReasonDeploymentHealthy        = "DeploymentHealthy"
+
+	ReasonFieldsTakenOver = "FieldsTakenOver"
+	ReasonFieldsYielded   = "FieldsYielded"
+	ReasonNoConflicts     = "NoConflicts"
+
+	ReasonGatewayConfigFound    = "GatewayConfigFound"
+	ReasonGatewayConfigNotFound = "GatewayConfigNotFound"
+
+	ReasonIngressClassUnique = "IngressClassUnique"
+
+	ReasonCRDsEmbedded    = "CRDsEmbedded"
+	ReasonCRDsNotEmbedded = "CRDsNotEmbedded"
+
+	ReasonComponentsReady    = "ComponentsReady"
+	ReasonComponentsNotReady = "ComponentsNotReady"
+)
+
+// SetReconcileError marks the ReconcileError condition as true with the reason of the failed step.
+func SetReconcileError(conditions *[]metav1.Condition, generation int64, reason string, err error) {
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               v1beta1.ConditionReconcileError,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            err.Error(),
+	})
+}
+
+// ClearReconcileError marks the ReconcileError condition as false after all steps succeeded.
+func ClearReconcileError(conditions *[]metav1.Condition, generation int64) {
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               v1beta1.ConditionReconcileError,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             ReasonReconcileSucceeded,
+		Message:            "All resources are reconciled",
+	})
+}
+
+// SetFieldConflicts marks the FieldConflict condition as true if any of the applied objects had
+// fields owned by other field managers, which were either left to them or taken over.
+func SetFieldConflicts(conditions *[]metav1.Condition, generation int64, conflicts []FieldConflict) {
+	if len(conflicts) == 0 {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               v1beta1.ConditionFieldConflict,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: generation,
+			Reason:             ReasonNoConflicts,
+			Message:            "No fields are owned by other field managers",
+		})
+		return
+	}
+
+	// the reason is FieldsTakenOver if the fields of any of the objects were taken over
+	reason := ReasonFieldsYielded
+	messages := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		messages = append(messages, conflict.String())
+		if conflict.Forced {
+			reason = ReasonFieldsTakenOver
+		}
+	}
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               v1beta1.ConditionFieldConflict,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            strings.Join(messages, "; "),
+	})
+}
+
+// SetGatewayConfigAvailable records whether the mesh config ConfigMap name of the gateway exists.
+func SetGatewayConfigAvailable(conditions *[]metav1.Condition, generation int64, name string, found bool) {
+	condition := metav1.Condition{
+		Type:               v1beta1.ConditionGatewayConfigAvailable,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             ReasonGatewayConfigFound,
+		Message:            fmt.Sprintf("ConfigMap %s exists", name),
+	}
+	if !found {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonGatewayConfigNotFound
+		condition.Message = fmt.Sprintf("Waiting for the gateway to create ConfigMap %s", name)
+	}
+	meta.SetStatusCondition(conditions, condition)
+}
+
+// SetIngressClassConflict records whether the ingress class is served by the older instance owner,
+// an empty owner means that there is no conflict.
+func SetIngressClassConflict(conditions *[]metav1.Condition, generation int64, ingressClass, owner string) {
+	condition := metav1.Condition{
+		Type:               v1beta1.ConditionIngressClassConflict,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             ReasonIngressClassUnique,
+		Message:            fmt.Sprintf("Ingress class %q is only served by this instance", ingressClass),
+	}
+	if owner != "" {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = ReasonIngressClassConflict
+		condition.Message = fmt.Sprintf("Ingress class %q is already served by %s", ingressClass, owner)
+	}
+	meta.SetStatusCondition(conditions, condition)
+}
+
+// SetCRDsSkipped records whether the CRD install is skipped, as no CRDs are embedded for the
+// release of the image for the reason in message. An empty message means that they are installed.
+func SetCRDsSkipped(conditions *[]metav1.Condition, generation int64, message string) {
+	condition := metav1.Condition{
+		Type:               v1beta1.ConditionCRDsSkipped,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             ReasonCRDsEmbedded,
+		Message:            "The CRDs of the release of the image are installed",
+	}
+	if message != "" {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = ReasonCRDsNotEmbedded
+		condition.Message = message
+	}
+	meta.SetStatusCondition(conditions, condition)
+}
+
+// SetComponentsReady marks the Ready condition of a Higress stack as true once all of its
+// components are ready.
+func SetComponentsReady(conditions *[]metav1.Condition, generation int64, components []v1beta1.ComponentStatus) {
+	var notReady []string
+	for _, component := range components {
+		if component.Ready != metav1.ConditionTrue {
+			notReady = append(notReady, fmt.Sprintf("%s %s", component.Kind, component.Name))
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:               v1beta1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             ReasonComponentsReady,
+		Message:            fmt.Sprintf("%d/%d components are ready", len(components), len(components)),
+	}
+	if len(notReady) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReasonComponentsNotReady
+		condition.Message = fmt.Sprintf("%d/%d components are ready, waiting for %s",
+			len(components)-len(notReady), len(components), strings.Join(notReady, ", "))
+	}
+	meta.SetStatusCondition(conditions, condition)
+}
+
+// SetDeploymentConditions derives the Ready, Progressing and Degraded conditions from the
+// rollout state of deploy. A nil deploy means the Deployment doesn't exist yet.
+func SetDeploymentConditions(conditions *[]metav1.Condition, generation int64, deploy *appsv1.Deployment) {
+	set := func(condType string, status metav1.ConditionStatus, reason, message string) {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               condType,
+			Status:             status,
+			ObservedGeneration: generation,
+			Reason:             reason,
+			Message:            message,
+		})
+	}
+
+	if deploy == nil {
+		set(v1beta1.ConditionReady, metav1.ConditionFalse, ReasonDeploymentNotFound, "Deployment has not been created yet")
+		set(v1beta1.ConditionProgressing, metav1.ConditionTrue, ReasonDeploymentNotFound, "Deployment has not been created yet")
+		set(v1beta1.ConditionDegraded, metav1.ConditionFalse, ReasonDeploymentNotFound, "Deployment has not been created yet")
+		return
+	}
+
+	desired := int32(1)
+	if deploy.Spec.Replicas != nil {
+		desired = *deploy.Spec.Replicas
+	}
+	status := deploy.Status
+
+	// ready once the current spec is rolled out to all desired replicas and they are available, the
+	// Available condition of the Deployment alone is already true with maxUnavailable pods missing
+	available := getDeploymentCondition(deploy, appsv1.DeploymentAvailable)
+	if available != nil && available.Status == apiv1.ConditionTrue && status.ObservedGeneration >= deploy.Generation &&
+		status.AvailableReplicas >= desired && status.UpdatedReplicas >= desired && status.UpdatedReplicas == status.Replicas {
+		set(v1beta1.ConditionReady, metav1.ConditionTrue, ReasonDeploymentAvailable,
+			fmt.Sprintf("%d/%d replicas are available", status.AvailableReplicas, desired))
+	} else {
+		message := fmt.Sprintf("%d/%d replicas are available", status.AvailableReplicas, desired)
+		if available != nil && available.Status != apiv1.ConditionTrue && available.Message != "" {
+			message = available.Message
+		}
+		set(v1beta1.ConditionReady, metav1.ConditionFalse, ReasonDeploymentUnavailable, message)
+	}
+
+	// progressing
+	if status.ObservedGeneration < deploy.Generation || status.UpdatedReplicas < desired ||
+		status.Replicas > status.UpdatedReplicas || status.AvailableReplicas < status.UpdatedReplicas {
+		set(v1beta1.ConditionProgressing, metav1.ConditionTrue, ReasonRollingOut,
+			fmt.Sprintf("%d of %d updated replicas are available", status.AvailableReplicas, desired))
+	} else {
+		set(v1beta1.ConditionProgressing, metav1.ConditionFalse, ReasonRolloutComplete,
+			fmt.Sprintf("Deployment %s has been rolled out", deploy.Name))
+	}
+
+	// degraded
+	progressing := getDeploymentCondition(deploy, appsv1.DeploymentProgressing)
+	replicaFailure := getDeploymentCondition(deploy, appsv1.DeploymentReplicaFailure)
+	switch {
+	case progressing != nil && progressing.Reason == ReasonProgressDeadlineExceeded:
+		set(v1beta1.ConditionDegraded, metav1.ConditionTrue, ReasonProgressDeadlineExceeded, progressing.Message)
+	case replicaFailure != nil && replicaFailure.Status == apiv1.ConditionTrue:
+		set(v1beta1.ConditionDegraded, metav1.ConditionTrue, ReasonReplicaFailure, replicaFailure.Message)
+	default:
+		set(v1beta1.ConditionDegraded, metav1.ConditionFalse, ReasonDeploymentHealthy, "Deployment is healthy")
+	}
+}
+
+func getDeploymentCondition(deploy *appsv1.Deployment, condType appsv1.DeploymentConditionType) *appsv1.DeploymentCondition {
+	for i := range deploy.Status.Conditions {
+		if deploy.Status.Conditions[i].Type == condType {
+			return &deploy.Status.Conditions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
+)
+
+// newDeployment returns a Deployment of 3 replicas in generation 2 with the given status, whose
+// Available condition is availableStatus.
+func newDeployment(status appsv1.DeploymentStatus, availableStatus apiv1.ConditionStatus) *appsv1.Deployment {
+	replicas := int32(3)
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "higress-gateway", Generation: 2},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     status,
+	}
+	deploy.Status.Conditions = append(deploy.Status.Conditions, appsv1.DeploymentCondition{
+		Type:   appsv1.DeploymentAvailable,
+		Status: availableStatus,
+	})
+	return deploy
+}
+
+func TestSetDeploymentConditions(t *testing.T) {
+	tests := []struct {
+		name            string
+		deploy          *appsv1.Deployment
+		wantReady       metav1.ConditionStatus
+		wantReason      string
+		wantProgressing metav1.ConditionStatus
+		wantDegraded    metav1.ConditionStatus
+	}{
+		{
+			name:            "no deployment",
+			wantReady:       metav1.ConditionFalse,
+			wantReason:      ReasonDeploymentNotFound,
+			wantProgressing: metav1.ConditionTrue,
+			wantDegraded:    metav1.ConditionFalse,
+		},
+		{
+			name: "rollout in progress",
+			deploy: newDeployment(appsv1.DeploymentStatus{
+				ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 2, ReadyReplicas: 3, AvailableReplicas: 3,
+			}, apiv1.ConditionTrue),
+			wantReady:       metav1.ConditionFalse,
+			wantReason:      ReasonDeploymentUnavailable,
+			wantProgressing: metav1.ConditionTrue,
+			wantDegraded:    metav1.ConditionFalse,
+		},
+		{
+			// the Available condition of the Deployment is true within maxUnavailable
+			name: "partially available",
+			deploy: newDeployment(appsv1.DeploymentStatus{
+				ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 1, AvailableReplicas: 1,
+			}, apiv1.ConditionTrue),
+			wantReady:       metav1.ConditionFalse,
+			wantReason:      ReasonDeploymentUnavailable,
+			wantProgressing: metav1.ConditionTrue,
+			wantDegraded:    metav1.ConditionFalse,
+		},
+		{
+			name: "fully available",
+			deploy: newDeployment(appsv1.DeploymentStatus{
+				ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3,
+			}, apiv1.ConditionTrue),
+			wantReady:       metav1.ConditionTrue,
+			wantReason:      ReasonDeploymentAvailable,
+			wantProgressing: metav1.ConditionFalse,
+			wantDegraded:    metav1.ConditionFalse,
+		},
+		{
+			// the status is the one of the previous spec
+			name: "observed generation mismatch",
+			deploy: newDeployment(appsv1.DeploymentStatus{
+				ObservedGeneration: 1, Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3,
+			}, apiv1.ConditionTrue),
+			wantReady:       metav1.ConditionFalse,
+			wantReason:      ReasonDeploymentUnavailable,
+			wantProgressing: metav1.ConditionTrue,
+			wantDegraded:    metav1.ConditionFalse,
+		},
+		{
+			name: "progress deadline exceeded",
+			deploy: func() *appsv1.Deployment {
+				deploy := newDeployment(appsv1.DeploymentStatus{
+					ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 1, AvailableReplicas: 0,
+				}, apiv1.ConditionFalse)
+				deploy.Status.Conditions = append(deploy.Status.Conditions, appsv1.DeploymentCondition{
+					Type:   appsv1.DeploymentProgressing,
+					Status: apiv1.ConditionFalse,
+					Reason: ReasonProgressDeadlineExceeded,
+				})
+				return deploy
+			}(),
+			wantReady:       metav1.ConditionFalse,
+			wantReason:      ReasonDeploymentUnavailable,
+			wantProgressing: metav1.ConditionTrue,
+			wantDegraded:    metav1.ConditionTrue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var conditions []metav1.Condition
+			SetDeploymentConditions(&conditions, 5, tt.deploy)
+
+			for condType, want := range map[string]metav1.ConditionStatus{
+				v1beta1.ConditionReady:       tt.wantReady,
+				v1beta1.ConditionProgressing: tt.wantProgressing,
+				v1beta1.ConditionDegraded:    tt.wantDegraded,
+			} {
+				condition := meta.FindStatusCondition(conditions, condType)
+				if condition == nil {
+					t.Fatalf("condition %s is not set", condType)
+				}
+				if condition.Status != want || condition.ObservedGeneration != 5 {
+					t.Errorf("condition %s = %s in generation %d, want %s in generation 5", condType,
+						condition.Status, condition.ObservedGeneration, want)
+				}
+			}
+			if ready := meta.FindStatusCondition(conditions, v1beta1.ConditionReady); ready.Reason != tt.wantReason {
+				t.Errorf("Ready reason = %s, want %s", ready.Reason, tt.wantReason)
+			}
+		})
+	}
+}
@@ -1,81 +1,46 @@
 package controller
 
 import (
-	"context"
 	"fmt"
+	"strings"
 
-	"github.com/go-logr/logr"
-	"github.com/google/go-cmp/cmp"
-	"k8s.io/apimachinery/pkg/api/equality"
-	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/alibaba/higress/higress-operator/api/v1alpha1"
+	"github.com/alibaba/higress/higress-operator/api/v1beta1"
 )
 
-func CreateOrUpdate(ctx context.Context, cli client.Client, kind string, object client.Object, f controllerutil.MutateFn, logger logr.Logger) error {
-	key := client.ObjectKeyFromObject(object)
-	status, err := controllerutil.CreateOrUpdate(ctx, cli, object, f)
-	if err != nil {
-		logger.Error(err, fmt.Sprintf("Failed to createOrUpdate object {%s:%s}", kind, key))
-		return err
-	}
+// internalAnnotations are the annotations that the operator keeps on its instances for itself.
+var internalAnnotations = []string{AnnotationDefaultsVersion, v1alpha1.AnnotationConversionData}
 
-	logger.Info(fmt.Sprintf("createOrUpdate object {%s:%s} : %s", kind, key, status))
-	return nil
-}
-
-// CreateIfNotExits create obj if not exists
-// true, nil : the obj exists
-func CreateIfNotExits(ctx context.Context, cli client.Client, object client.Object) (bool, error) {
-	var err error
-	if err = cli.Create(ctx, object); err != nil && errors.IsAlreadyExists(err) {
-		return true, nil
-	}
+// internalLabels are the labels that the operator sets on the objects it manages for itself.
+var internalLabels = []string{LabelOwnerKind, LabelOwnerNamespace, LabelOwnerName, LabelGatewayConfigOf}
 
-	return false, err
+// ObjectLabels returns the labels of instance to set on the objects that it owns, which are the
+// labels of the instance without the internal ones of the operator.
+func ObjectLabels(instance metav1.Object) map[string]string {
+	return withoutKeys(instance.GetLabels(), internalLabels)
 }
 
-func createOrUpdate(ctx context.Context, c client.Client, obj client.Object, f controllerutil.MutateFn, logger logr.Logger) (controllerutil.OperationResult, error) {
-	key := client.ObjectKeyFromObject(obj)
-	if err := c.Get(ctx, key, obj); err != nil {
-		if !errors.IsNotFound(err) {
-			return controllerutil.OperationResultNone, err
-		}
-		if err := mutate(f, key, obj); err != nil {
-			return controllerutil.OperationResultNone, err
-		}
-		if err := c.Create(ctx, obj); err != nil {
-			return controllerutil.OperationResultNone, err
-		}
-		return controllerutil.OperationResultCreated, nil
-	}
-
-	existing := obj.DeepCopyObject()
-	if err := mutate(f, key, obj); err != nil {
-		return controllerutil.OperationResultNone, err
-	}
-
-	if equality.Semantic.DeepEqual(existing, obj) {
-		return controllerutil.OperationResultNone, nil
-	}
-
-	logger.Info(fmt.Sprintf("the diff of %v is %v", key, cmp.Diff(obj, existing)))
-
-	if err := c.Update(ctx, obj); err != nil {
-		return controllerutil.OperationResultNone, err
-	}
-	return controllerutil.OperationResultUpdated, nil
+// ObjectAnnotations returns the annotations of instance to set on the objects that it owns, which
+// are the annotations of the instance without the internal ones of the operator.
+func ObjectAnnotations(instance metav1.Object) map[string]string {
+	return withoutKeys(instance.GetAnnotations(), internalAnnotations)
 }
 
-func mutate(f controllerutil.MutateFn, key client.ObjectKey, obj client.Object) error {
-	if err := f(); err != nil {
-		return err
+// withoutKeys returns a copy of m without keys, or nil if nothing is left.
+func withoutKeys(m map[string]string, keys []string) map[string]string {
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = v
 	}
-	if newKey := client.ObjectKeyFromObject(obj); key != newKey {
-		return fmt.Errorf("MutateFn cannot mutate object name and/or object namespace")
+	for _, k := range keys {
+		delete(result, k)
 	}
-	return nil
+	if len(result) == 0 {
+		return nil
+	}
+	return result
 }
 
 func UpdateObjectMeta(obj *metav1.ObjectMeta, instance metav1.Object, labels map[string]string) {
@@ -83,3 +48,20 @@ func UpdateObjectMeta(obj *metav1.ObjectMeta, instance metav1.Object, labels map
 	obj.Namespace = instance.GetNamespace()
 	obj.Labels = labels
 }
+
+// AutoScalingEnabled returns true if the replicas of the workload are managed by an autoscaler.
+func AutoScalingEnabled(spec *v1beta1.CRDCommonFields) bool {
+	return spec.AutoScaling != nil && spec.AutoScaling.Enable
+}
+
+// ImageReference returns the reference of the image repository with tag, which is a tag, a digest
+// or a tag followed by @ and a digest.
+func ImageReference(repository, tag string) string {
+	switch {
+	case strings.HasPrefix(tag, "@"):
+		return repository + tag
+	case strings.Contains(tag, ":") && !strings.Contains(tag, "@"):
+		return fmt.Sprintf("%v@%v", repository, tag)
+	}
+	return fmt.Sprintf("%v:%v", repository, tag)
+}
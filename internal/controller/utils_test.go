@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/alibaba/higress/higress-operator/api/v1alpha1"
+)
+
+func TestImageReference(t *testing.T) {
+	const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{tag: "1.3.0", want: "higress/gateway:1.3.0"},
+		{tag: digest, want: "higress/gateway@" + digest},
+		{tag: "@" + digest, want: "higress/gateway@" + digest},
+		{tag: "1.3.0@" + digest, want: "higress/gateway:1.3.0@" + digest},
+	}
+
+	for _, tt := range tests {
+		if got := ImageReference("higress/gateway", tt.tag); got != tt.want {
+			t.Errorf("ImageReference(%q) = %q, want %q", tt.tag, got, tt.want)
+		}
+	}
+}
+
+func TestObjectAnnotations(t *testing.T) {
+	instance := &metav1.ObjectMeta{Annotations: map[string]string{
+		"team":                            "edge",
+		AnnotationDefaultsVersion:         "2",
+		v1alpha1.AnnotationConversionData: "{}",
+	}}
+
+	annotations := ObjectAnnotations(instance)
+	if !reflect.DeepEqual(annotations, map[string]string{"team": "edge"}) {
+		t.Errorf("ObjectAnnotations() = %v, want only the annotations of the user", annotations)
+	}
+	if len(instance.Annotations) != 3 {
+		t.Errorf("ObjectAnnotations() changed the annotations of the instance to %v", instance.Annotations)
+	}
+
+	delete(instance.Annotations, "team")
+	if annotations = ObjectAnnotations(instance); annotations != nil {
+		t.Errorf("ObjectAnnotations() = %v, want nil", annotations)
+	}
+}
+
+func TestObjectLabels(t *testing.T) {
+	instance := &metav1.ObjectMeta{Labels: map[string]string{
+		"app":               "higress",
+		LabelOwnerKind:      "Higress",
+		LabelOwnerNamespace: "tenant",
+		LabelOwnerName:      "higress",
+	}}
+
+	if labels := ObjectLabels(instance); !reflect.DeepEqual(labels, map[string]string{"app": "higress"}) {
+		t.Errorf("ObjectLabels() = %v, want only the labels of the user", labels)
+	}
+}
@@ -0,0 +1,43 @@
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/alibaba/higress/higress-operator/internal/controller"
+)
+
+// recordDefaultsVersion records the defaults version of obj if it has none. New instances get the
+// latest version, and the instances created before the versions were recorded keep the initial one,
+// as they have been running with its defaults.
+func recordDefaultsVersion(ctx context.Context, obj client.Object, latest int) {
+	if _, ok := obj.GetAnnotations()[controller.AnnotationDefaultsVersion]; ok {
+		return
+	}
+
+	version := controller.InitialDefaultsVersion
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.Operation == admissionv1.Create {
+		version = latest
+	}
+	controller.SetDefaultsVersion(obj, version)
+}
+
+// isStorageMigration returns true if the request is a patch of the storage migration, which only
+// stores the object in the storage version and so is neither defaulted nor validated.
+func isStorageMigration(ctx context.Context) bool {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil || req.Operation != admissionv1.Update {
+		return false
+	}
+
+	options := &metav1.PatchOptions{}
+	if err = json.Unmarshal(req.Options.Raw, options); err != nil {
+		return false
+	}
+	return options.FieldManager == controller.StorageMigrationFieldManager
+}
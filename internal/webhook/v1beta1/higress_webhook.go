@@ -0,0 +1,140 @@
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
+	"github.com/alibaba/higress/higress-operator/internal/controller/higress"
+)
+
+// log is for logging in this package.
+var higresslog = logf.Log.WithName("higress-resource")
+
+// SetupHigressWebhookWithManager registers the webhooks of Higress in the manager.
+func SetupHigressWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&operatorv1beta1.Higress{}).
+		WithDefaulter(&HigressCustomDefaulter{}).
+		WithValidator(&HigressCustomValidator{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-operator-higress-io-v1beta1-higress,mutating=true,failurePolicy=fail,sideEffects=None,groups=operator.higress.io,resources=higresses,verbs=create;update,versions=v1beta1,name=mhigress.kb.io,admissionReviewVersions=v1
+
+// HigressCustomDefaulter stores the defaults of the Higress instances, so that the stored objects
+// show their effective configuration.
+type HigressCustomDefaulter struct{}
+
+var _ admission.CustomDefaulter = &HigressCustomDefaulter{}
+
+func (d *HigressCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	instance, ok := obj.(*operatorv1beta1.Higress)
+	if !ok {
+		return fmt.Errorf("expected a Higress object but got %T", obj)
+	}
+	higresslog.Info("default", "name", instance.Name)
+
+	recordDefaultsVersion(ctx, instance, higress.LatestDefaultsVersion)
+	higress.SetDefaults(instance)
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-operator-higress-io-v1beta1-higress,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.higress.io,resources=higresses,verbs=create;update,versions=v1beta1,name=vhigress.kb.io,admissionReviewVersions=v1
+
+// HigressCustomValidator rejects the Higress specs whose components can't be deployed, which would
+// otherwise only fail in the reconciles of the components.
+type HigressCustomValidator struct{}
+
+var _ admission.CustomValidator = &HigressCustomValidator{}
+
+func (v *HigressCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	instance, ok := obj.(*operatorv1beta1.Higress)
+	if !ok {
+		return nil, fmt.Errorf("expected a Higress object but got %T", obj)
+	}
+	higresslog.Info("validate create", "name", instance.Name)
+
+	return higressWarnings(instance), toInvalid("Higress", instance.Name, validateHigress(instance))
+}
+
+func (v *HigressCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	instance, ok := newObj.(*operatorv1beta1.Higress)
+	if !ok {
+		return nil, fmt.Errorf("expected a Higress object for the newObj but got %T", newObj)
+	}
+	higresslog.Info("validate update", "name", instance.Name)
+
+	return higressWarnings(instance), toInvalid("Higress", instance.Name, validateHigress(instance))
+}
+
+func (v *HigressCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// higressWarnings warns about the controller images whose CRDs are not embedded.
+func higressWarnings(instance *operatorv1beta1.Higress) admission.Warnings {
+	controllerTag := instance.Spec.Tag
+	if instance.Spec.Controller.Tag != "" {
+		controllerTag = instance.Spec.Controller.Tag
+	}
+	return crdWarnings(controllerTag, "")
+}
+
+func validateHigress(instance *operatorv1beta1.Higress) field.ErrorList {
+	spec := &instance.Spec
+	path := field.NewPath("spec")
+
+	errs := validateImageTag(spec.Tag, path.Child("tag"))
+	for _, msg := range validation.IsDNS1123Subdomain(spec.IngressClass) {
+		errs = append(errs, field.Invalid(path.Child("ingressClass"), spec.IngressClass, msg))
+	}
+
+	errs = append(errs, validateGatewayAPI(spec.GatewayAPI, true, path.Child("gatewayAPI"))...)
+
+	errs = append(errs, validateComponent(&spec.Controller, path.Child("controller"))...)
+	errs = append(errs, validateComponentTag(spec.Pilot.Tag, path.Child("pilot", "tag"))...)
+
+	gatewayPath := path.Child("gateway")
+	errs = append(errs, validateComponent(&spec.Gateway.HigressComponent, gatewayPath)...)
+	errs = append(errs, validateAutoScaling(spec.Gateway.AutoScaling, gatewayPath.Child("autoScaling"))...)
+	errs = append(errs, validateContainerPorts(spec.Gateway.Ports, spec.Gateway.HostNetwork, gatewayPath.Child("ports"))...)
+	if service := spec.Gateway.Service; service != nil {
+		errs = append(errs, validateServicePorts(service.Ports, gatewayPath.Child("service", "ports"))...)
+	}
+
+	if console := spec.Console; console != nil {
+		errs = append(errs, validateComponent(&console.HigressComponent, path.Child("console"))...)
+		if budget := console.PodDisruptionBudget; budget != nil && budget.Enable {
+			errs = append(errs, field.Forbidden(path.Child("console", "podDisruptionBudget", "enable"), "is not supported by HigressConsole"))
+		}
+	}
+
+	return errs
+}
+
+func validateComponent(component *operatorv1beta1.HigressComponent, path *field.Path) field.ErrorList {
+	errs := validateComponentTag(component.Tag, path.Child("tag"))
+	if component.Replicas != nil && *component.Replicas < 0 {
+		errs = append(errs, field.Invalid(path.Child("replicas"), *component.Replicas, "must be greater than or equal to 0"))
+	}
+	errs = append(errs, validateScheduling(component.TopologySpreadConstraints, component.PriorityClassName,
+		component.TerminationGracePeriodSeconds, path)...)
+	errs = append(errs, validatePodDisruptionBudget(component.PodDisruptionBudget, path.Child("podDisruptionBudget"))...)
+	return errs
+}
+
+// validateComponentTag validates the tag that overrides the tag of the stack, if any.
+func validateComponentTag(tag string, path *field.Path) field.ErrorList {
+	if tag == "" {
+		return nil
+	}
+	return validateImageTag(tag, path)
+}
@@ -0,0 +1,115 @@
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
+	"github.com/alibaba/higress/higress-operator/internal/controller/higressconsole"
+)
+
+// log is for logging in this package.
+var higressconsolelog = logf.Log.WithName("higressconsole-resource")
+
+// SetupHigressConsoleWebhookWithManager registers the webhooks of HigressConsole in the manager.
+func SetupHigressConsoleWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&operatorv1beta1.HigressConsole{}).
+		WithDefaulter(&HigressConsoleCustomDefaulter{}).
+		WithValidator(&HigressConsoleCustomValidator{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-operator-higress-io-v1beta1-higressconsole,mutating=true,failurePolicy=fail,sideEffects=None,groups=operator.higress.io,resources=higressconsoles,verbs=create;update,versions=v1beta1,name=mhigressconsole.kb.io,admissionReviewVersions=v1
+
+// HigressConsoleCustomDefaulter stores the defaults of the HigressConsole instances, so that the
+// stored objects show their effective configuration.
+type HigressConsoleCustomDefaulter struct{}
+
+var _ admission.CustomDefaulter = &HigressConsoleCustomDefaulter{}
+
+func (d *HigressConsoleCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	console, ok := obj.(*operatorv1beta1.HigressConsole)
+	if !ok {
+		return fmt.Errorf("expected a HigressConsole object but got %T", obj)
+	}
+	higressconsolelog.Info("default", "name", console.Name)
+
+	// the defaults derived from the name are left to the reconciles until a generated name is known
+	if console.Name == "" {
+		return nil
+	}
+
+	recordDefaultsVersion(ctx, console, higressconsole.LatestDefaultsVersion)
+	higressconsole.SetDefaults(console)
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-operator-higress-io-v1beta1-higressconsole,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.higress.io,resources=higressconsoles,verbs=create;update,versions=v1beta1,name=vhigressconsole.kb.io,admissionReviewVersions=v1
+
+// HigressConsoleCustomValidator rejects the HigressConsole specs that can't be deployed, which
+// would otherwise only fail in the reconciles.
+type HigressConsoleCustomValidator struct{}
+
+var _ admission.CustomValidator = &HigressConsoleCustomValidator{}
+
+func (v *HigressConsoleCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	console, ok := obj.(*operatorv1beta1.HigressConsole)
+	if !ok {
+		return nil, fmt.Errorf("expected a HigressConsole object but got %T", obj)
+	}
+	higressconsolelog.Info("validate create", "name", console.Name)
+
+	return nil, toInvalid("HigressConsole", console.Name, validateHigressConsole(console))
+}
+
+func (v *HigressConsoleCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	console, ok := newObj.(*operatorv1beta1.HigressConsole)
+	if !ok {
+		return nil, fmt.Errorf("expected a HigressConsole object for the newObj but got %T", newObj)
+	}
+	old, ok := oldObj.(*operatorv1beta1.HigressConsole)
+	if !ok {
+		return nil, fmt.Errorf("expected a HigressConsole object for the oldObj but got %T", oldObj)
+	}
+	higressconsolelog.Info("validate update", "name", console.Name)
+
+	old = old.DeepCopy()
+	higressconsole.SetDefaults(old)
+
+	errs := validateHigressConsole(console)
+	errs = append(errs, validateSelectorLabelsUpdate(&console.Spec.CRDCommonFields, &old.Spec.CRDCommonFields, field.NewPath("spec"))...)
+	return nil, toInvalid("HigressConsole", console.Name, errs)
+}
+
+func (v *HigressConsoleCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateHigressConsole(console *operatorv1beta1.HigressConsole) field.ErrorList {
+	spec := &console.Spec
+	path := field.NewPath("spec")
+
+	errs := validateCommonFields(&spec.CRDCommonFields, path)
+	errs = append(errs, validateContainer(&spec.ContainerCommonFields, false, path)...)
+
+	// no HorizontalPodAutoscaler or PodDisruptionBudget is managed for the console
+	if autoScaling := spec.AutoScaling; autoScaling != nil && autoScaling.Enable {
+		errs = append(errs, field.Forbidden(path.Child("autoScaling", "enable"), "is not supported by HigressConsole"))
+	}
+	if budget := spec.PodDisruptionBudget; budget != nil && budget.Enable {
+		errs = append(errs, field.Forbidden(path.Child("podDisruptionBudget", "enable"), "is not supported by HigressConsole"))
+	}
+
+	if ref := spec.ControllerRef; ref != nil && ref.Name == "" {
+		errs = append(errs, field.Required(path.Child("controllerRef", "name"), ""))
+	}
+
+	return errs
+}
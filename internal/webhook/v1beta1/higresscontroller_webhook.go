@@ -0,0 +1,124 @@
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
+	"github.com/alibaba/higress/higress-operator/internal/controller/higresscontroller"
+)
+
+// log is for logging in this package.
+var higresscontrollerlog = logf.Log.WithName("higresscontroller-resource")
+
+// SetupHigressControllerWebhookWithManager registers the webhooks of HigressController in the manager.
+func SetupHigressControllerWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&operatorv1beta1.HigressController{}).
+		WithDefaulter(&HigressControllerCustomDefaulter{}).
+		WithValidator(&HigressControllerCustomValidator{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-operator-higress-io-v1beta1-higresscontroller,mutating=true,failurePolicy=fail,sideEffects=None,groups=operator.higress.io,resources=higresscontrollers,verbs=create;update,versions=v1beta1,name=mhigresscontroller.kb.io,admissionReviewVersions=v1
+
+// HigressControllerCustomDefaulter stores the defaults of the HigressController instances, so that the stored
+// objects show their effective configuration.
+type HigressControllerCustomDefaulter struct{}
+
+var _ admission.CustomDefaulter = &HigressControllerCustomDefaulter{}
+
+func (d *HigressControllerCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	controller, ok := obj.(*operatorv1beta1.HigressController)
+	if !ok {
+		return fmt.Errorf("expected a HigressController object but got %T", obj)
+	}
+	higresscontrollerlog.Info("default", "name", controller.Name)
+
+	if isStorageMigration(ctx) {
+		return nil
+	}
+
+	recordDefaultsVersion(ctx, controller, higresscontroller.LatestDefaultsVersion)
+	higresscontroller.SetDefaults(controller)
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-operator-higress-io-v1beta1-higresscontroller,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.higress.io,resources=higresscontrollers,verbs=create;update,versions=v1beta1,name=vhigresscontroller.kb.io,admissionReviewVersions=v1
+
+// HigressControllerCustomValidator rejects the HigressController specs that can't be deployed,
+// which would otherwise only fail in the reconciles.
+type HigressControllerCustomValidator struct{}
+
+var _ admission.CustomValidator = &HigressControllerCustomValidator{}
+
+func (v *HigressControllerCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	controller, ok := obj.(*operatorv1beta1.HigressController)
+	if !ok {
+		return nil, fmt.Errorf("expected a HigressController object but got %T", obj)
+	}
+	higresscontrollerlog.Info("validate create", "name", controller.Name)
+
+	return crdWarnings(controller.Spec.Controller.Image.Tag, controller.Spec.CRDInstallPolicy),
+		toInvalid("HigressController", controller.Name, validateHigressController(controller))
+}
+
+func (v *HigressControllerCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	controller, ok := newObj.(*operatorv1beta1.HigressController)
+	if !ok {
+		return nil, fmt.Errorf("expected a HigressController object for the newObj but got %T", newObj)
+	}
+	old, ok := oldObj.(*operatorv1beta1.HigressController)
+	if !ok {
+		return nil, fmt.Errorf("expected a HigressController object for the oldObj but got %T", oldObj)
+	}
+	higresscontrollerlog.Info("validate update", "name", controller.Name)
+
+	if isStorageMigration(ctx) && equality.Semantic.DeepEqual(old.Spec, controller.Spec) {
+		return nil, nil
+	}
+
+	// the selector labels of the instances stored before the defaulting webhook are the defaults
+	old = old.DeepCopy()
+	higresscontroller.SetDefaults(old)
+
+	errs := validateHigressController(controller)
+	errs = append(errs, validateSelectorLabelsUpdate(&controller.Spec.CRDCommonFields, &old.Spec.CRDCommonFields, field.NewPath("spec"))...)
+	return crdWarnings(controller.Spec.Controller.Image.Tag, controller.Spec.CRDInstallPolicy), toInvalid("HigressController", controller.Name, errs)
+}
+
+func (v *HigressControllerCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateHigressController(controller *operatorv1beta1.HigressController) field.ErrorList {
+	spec := &controller.Spec
+	path := field.NewPath("spec")
+
+	errs := validateCommonFields(&spec.CRDCommonFields, path)
+
+	controllerPath := path.Child("controller")
+	errs = append(errs, validateContainer(&spec.Controller.ContainerCommonFields, false, controllerPath)...)
+	for _, msg := range validation.IsDNS1123Subdomain(spec.Controller.IngressClass) {
+		errs = append(errs, field.Invalid(controllerPath.Child("ingressClass"), spec.Controller.IngressClass, msg))
+	}
+	if !spec.EnableHigressIstio {
+		errs = append(errs, validateContainer(&spec.Pilot.ContainerCommonFields, false, path.Child("pilot"))...)
+		if spec.JwtPolicy == thirdPartyJwt && spec.Controller.SDSTokenAud == "" {
+			errs = append(errs, field.Required(controllerPath.Child("sdsTokenAud"),
+				"must be set as the audience of the tokens when jwtPolicy is third-party-jwt"))
+		}
+	}
+
+	errs = append(errs, validateGatewayAPI(spec.GatewayAPI, spec.CRDInstallPolicy != operatorv1beta1.CRDInstallPolicySkip, path.Child("gatewayAPI"))...)
+
+	return errs
+}
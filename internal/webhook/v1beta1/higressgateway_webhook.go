@@ -0,0 +1,127 @@
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
+	"github.com/alibaba/higress/higress-operator/internal/controller/higressgateway"
+)
+
+// log is for logging in this package.
+var higressgatewaylog = logf.Log.WithName("higressgateway-resource")
+
+// SetupHigressGatewayWebhookWithManager registers the webhooks of HigressGateway in the manager.
+func SetupHigressGatewayWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&operatorv1beta1.HigressGateway{}).
+		WithDefaulter(&HigressGatewayCustomDefaulter{}).
+		WithValidator(&HigressGatewayCustomValidator{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-operator-higress-io-v1beta1-higressgateway,mutating=true,failurePolicy=fail,sideEffects=None,groups=operator.higress.io,resources=higressgateways,verbs=create;update,versions=v1beta1,name=mhigressgateway.kb.io,admissionReviewVersions=v1
+
+// HigressGatewayCustomDefaulter stores the defaults of the HigressGateway instances, so that the
+// stored objects show their effective configuration.
+type HigressGatewayCustomDefaulter struct{}
+
+var _ admission.CustomDefaulter = &HigressGatewayCustomDefaulter{}
+
+func (d *HigressGatewayCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	gateway, ok := obj.(*operatorv1beta1.HigressGateway)
+	if !ok {
+		return fmt.Errorf("expected a HigressGateway object but got %T", obj)
+	}
+	higressgatewaylog.Info("default", "name", gateway.Name)
+
+	// the defaults derived from the name are left to the reconciles until a generated name is known
+	if gateway.Name == "" {
+		return nil
+	}
+
+	if isStorageMigration(ctx) {
+		return nil
+	}
+
+	recordDefaultsVersion(ctx, gateway, higressgateway.LatestDefaultsVersion)
+	higressgateway.SetDefaults(gateway)
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-operator-higress-io-v1beta1-higressgateway,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.higress.io,resources=higressgateways,verbs=create;update,versions=v1beta1,name=vhigressgateway.kb.io,admissionReviewVersions=v1
+
+// HigressGatewayCustomValidator rejects the HigressGateway specs that can't be deployed, which
+// would otherwise only fail in the reconciles.
+type HigressGatewayCustomValidator struct{}
+
+var _ admission.CustomValidator = &HigressGatewayCustomValidator{}
+
+func (v *HigressGatewayCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	gateway, ok := obj.(*operatorv1beta1.HigressGateway)
+	if !ok {
+		return nil, fmt.Errorf("expected a HigressGateway object but got %T", obj)
+	}
+	higressgatewaylog.Info("validate create", "name", gateway.Name)
+
+	return nil, toInvalid("HigressGateway", gateway.Name, validateHigressGateway(gateway))
+}
+
+func (v *HigressGatewayCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	gateway, ok := newObj.(*operatorv1beta1.HigressGateway)
+	if !ok {
+		return nil, fmt.Errorf("expected a HigressGateway object for the newObj but got %T", newObj)
+	}
+	old, ok := oldObj.(*operatorv1beta1.HigressGateway)
+	if !ok {
+		return nil, fmt.Errorf("expected a HigressGateway object for the oldObj but got %T", oldObj)
+	}
+	higressgatewaylog.Info("validate update", "name", gateway.Name)
+
+	if isStorageMigration(ctx) && equality.Semantic.DeepEqual(old.Spec, gateway.Spec) {
+		return nil, nil
+	}
+
+	// the selector labels of the instances stored before the defaulting webhook are the defaults
+	old = old.DeepCopy()
+	higressgateway.SetDefaults(old)
+
+	errs := validateHigressGateway(gateway)
+	errs = append(errs, validateSelectorLabelsUpdate(&gateway.Spec.CRDCommonFields, &old.Spec.CRDCommonFields, field.NewPath("spec"))...)
+	return nil, toInvalid("HigressGateway", gateway.Name, errs)
+}
+
+func (v *HigressGatewayCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateHigressGateway(gateway *operatorv1beta1.HigressGateway) field.ErrorList {
+	spec := &gateway.Spec
+	path := field.NewPath("spec")
+
+	errs := validateCommonFields(&spec.CRDCommonFields, path)
+	errs = append(errs, validateContainer(&spec.ContainerCommonFields, spec.HostNetwork, path)...)
+
+	if skywalking := spec.Skywalking; skywalking != nil && skywalking.Enable {
+		bootstrapPath := path.Child("skywalking", "customBootStrap")
+		if skywalking.CustomBootStrap == "" {
+			errs = append(errs, field.Required(bootstrapPath, "must be set when skywalking is enabled"))
+		} else if !json.Valid([]byte(skywalking.CustomBootStrap)) {
+			errs = append(errs, field.Invalid(bootstrapPath, "", "must be a JSON envoy bootstrap"))
+		}
+	}
+
+	if ref := spec.ControllerRef; ref != nil && ref.Name == "" {
+		errs = append(errs, field.Required(path.Child("controllerRef", "name"), ""))
+	}
+
+	return errs
+}
@@ -0,0 +1,205 @@
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
+	"github.com/alibaba/higress/higress-operator/internal/controller"
+	"github.com/alibaba/higress/higress-operator/internal/controller/higressgateway"
+)
+
+func newGateway() *operatorv1beta1.HigressGateway {
+	gateway := &operatorv1beta1.HigressGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "edge", Namespace: "tenant"},
+	}
+	gateway.Spec.Image = operatorv1beta1.Image{Repository: "higress-registry.cn-hangzhou.cr.aliyuncs.com/higress/gateway", Tag: "1.3.0"}
+	// defaulted by the CRD schema
+	gateway.Spec.JwtPolicy = "third-party-jwt"
+	return gateway
+}
+
+func requestContext(operation admissionv1.Operation) context.Context {
+	return admission.NewContextWithRequest(context.Background(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{Operation: operation},
+	})
+}
+
+func TestHigressGatewayDefaultRecordsDefaultsVersion(t *testing.T) {
+	defaulter := &HigressGatewayCustomDefaulter{}
+
+	// new instances get the latest defaults
+	gateway := newGateway()
+	if err := defaulter.Default(requestContext(admissionv1.Create), gateway); err != nil {
+		t.Fatalf("Default() error = %v", err)
+	}
+	if got := controller.DefaultsVersion(gateway); got != higressgateway.LatestDefaultsVersion {
+		t.Errorf("defaults version = %d, want %d", got, higressgateway.LatestDefaultsVersion)
+	}
+	if got := gateway.Spec.SelectorLabels["app"]; got != "edge" {
+		t.Errorf("selectorLabels = %v, want the ones derived from the name", gateway.Spec.SelectorLabels)
+	}
+
+	// the instances stored before the versions were recorded keep the initial defaults
+	gateway = newGateway()
+	if err := defaulter.Default(requestContext(admissionv1.Update), gateway); err != nil {
+		t.Fatalf("Default() error = %v", err)
+	}
+	if got := gateway.Annotations[controller.AnnotationDefaultsVersion]; got != "1" {
+		t.Errorf("defaults version = %q, want 1", got)
+	}
+	if got := gateway.Spec.SelectorLabels["app"]; got != "higress-gateway" {
+		t.Errorf("selectorLabels = %v, want the initial ones", gateway.Spec.SelectorLabels)
+	}
+
+	// a recorded version is kept
+	gateway = newGateway()
+	controller.SetDefaultsVersion(gateway, 1)
+	if err := defaulter.Default(requestContext(admissionv1.Create), gateway); err != nil {
+		t.Fatalf("Default() error = %v", err)
+	}
+	if got := controller.DefaultsVersion(gateway); got != 1 {
+		t.Errorf("defaults version = %d, want 1", got)
+	}
+}
+
+func TestHigressGatewayValidateCreate(t *testing.T) {
+	validator := &HigressGatewayCustomValidator{}
+	negative := int32(-1)
+
+	tests := []struct {
+		name   string
+		modify func(gateway *operatorv1beta1.HigressGateway)
+		want   []string
+	}{
+		{name: "valid", modify: func(*operatorv1beta1.HigressGateway) {}},
+		{
+			name: "digest",
+			modify: func(gateway *operatorv1beta1.HigressGateway) {
+				gateway.Spec.Image.Tag = "1.3.0@sha256:" + strings.Repeat("0f", 32)
+			},
+		},
+		{
+			name: "invalid fields",
+			modify: func(gateway *operatorv1beta1.HigressGateway) {
+				gateway.Spec.Replicas = &negative
+				gateway.Spec.Image.Tag = "1.3.0:latest"
+				gateway.Spec.ControllerRef = &operatorv1beta1.ControllerReference{}
+			},
+			want: []string{"spec.replicas", "spec.image.tag", "spec.controllerRef.name"},
+		},
+		{
+			name: "skywalking without bootstrap",
+			modify: func(gateway *operatorv1beta1.HigressGateway) {
+				gateway.Spec.Skywalking = &operatorv1beta1.Skywalking{Enable: true}
+			},
+			want: []string{"spec.skywalking.customBootStrap"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway := newGateway()
+			controller.SetDefaultsVersion(gateway, higressgateway.LatestDefaultsVersion)
+			higressgateway.SetDefaults(gateway)
+			tt.modify(gateway)
+
+			_, err := validator.ValidateCreate(context.Background(), gateway)
+			assertInvalidFields(t, err, tt.want)
+		})
+	}
+}
+
+func TestHigressGatewayValidateUpdateSelectorLabels(t *testing.T) {
+	validator := &HigressGatewayCustomValidator{}
+
+	// an instance stored before the defaulting webhook has no selector labels, which are the
+	// defaults of its version
+	old := newGateway()
+	gateway := newGateway()
+	controller.SetDefaultsVersion(gateway, controller.InitialDefaultsVersion)
+	higressgateway.SetDefaults(gateway)
+	if _, err := validator.ValidateUpdate(context.Background(), old, gateway); err != nil {
+		t.Errorf("ValidateUpdate() with the defaulted selector labels error = %v", err)
+	}
+
+	changed := gateway.DeepCopy()
+	changed.Spec.SelectorLabels = map[string]string{"app": "edge"}
+	_, err := validator.ValidateUpdate(context.Background(), gateway, changed)
+	assertInvalidFields(t, err, []string{"spec.selectorLabels"})
+
+	removed := gateway.DeepCopy()
+	removed.Spec.SelectorLabels = nil
+	_, err = validator.ValidateUpdate(context.Background(), gateway, removed)
+	assertInvalidFields(t, err, []string{"spec.selectorLabels"})
+}
+
+// assertInvalidFields checks that err is an Invalid error of exactly the fields want, or nil if
+// want is empty.
+func assertInvalidFields(t *testing.T, err error, want []string) {
+	t.Helper()
+	if len(want) == 0 {
+		if err != nil {
+			t.Errorf("error = %v, want nil", err)
+		}
+		return
+	}
+	if !apierrors.IsInvalid(err) {
+		t.Fatalf("error = %v, want an Invalid error", err)
+	}
+
+	causes := err.(apierrors.APIStatus).Status().Details.Causes
+	got := map[string]bool{}
+	for _, cause := range causes {
+		got[cause.Field] = true
+	}
+	for _, f := range want {
+		if !got[f] {
+			t.Errorf("error = %v, want an error of %s", err, f)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("error = %v, want errors of %v", err, want)
+	}
+}
+
+func TestHigressGatewayStorageMigration(t *testing.T) {
+	options, err := json.Marshal(&metav1.PatchOptions{FieldManager: controller.StorageMigrationFieldManager})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := admission.NewContextWithRequest(context.Background(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Update,
+			Options:   runtime.RawExtension{Raw: options},
+		},
+	})
+
+	// an instance stored before the webhooks, which the current validation rejects
+	old := newGateway()
+	old.Spec.Image.Tag = "1.3.0:latest"
+	gateway := old.DeepCopy()
+
+	if err = (&HigressGatewayCustomDefaulter{}).Default(ctx, gateway); err != nil {
+		t.Fatalf("Default() error = %v", err)
+	}
+	if len(gateway.Annotations) != 0 || gateway.Spec.SelectorLabels != nil {
+		t.Errorf("Default() changed the migrated instance to %+v", gateway)
+	}
+	if _, err = (&HigressGatewayCustomValidator{}).ValidateUpdate(ctx, old, gateway); err != nil {
+		t.Errorf("ValidateUpdate() error = %v, want the migration allowed", err)
+	}
+
+	// changes with the field manager of the migration are still validated, without the defaults
+	gateway.Spec.Replicas = new(int32)
+	_, err = (&HigressGatewayCustomValidator{}).ValidateUpdate(ctx, old, gateway)
+	assertInvalidFields(t, err, []string{"spec.image.tag", "spec.selectorLabels"})
+}
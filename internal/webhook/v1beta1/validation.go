@@ -0,0 +1,295 @@
+package v1beta1
+
+import (
+	"fmt"
+	"regexp"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	operatorv1beta1 "github.com/alibaba/higress/higress-operator/api/v1beta1"
+	"github.com/alibaba/higress/higress-operator/internal/controller/crds"
+)
+
+// imageTagPattern is the grammar of the image tags: a tag, a digest, optionally prefixed with @, or
+// a tag followed by @ and a digest.
+var imageTagPattern = regexp.MustCompile(`^(?:[\w][\w.-]{0,127}|@?` + digest + `|[\w][\w.-]{0,127}@` + digest + `)$`)
+
+// digest is the grammar of the digests of the image references.
+const digest = `[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,}`
+
+const (
+	thirdPartyJwt = "third-party-jwt"
+	firstPartyJwt = "first-party-jwt"
+)
+
+// validateCommonFields validates the fields shared by HigressGateway, HigressController and HigressConsole.
+func validateCommonFields(spec *operatorv1beta1.CRDCommonFields, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if spec.Replicas != nil && *spec.Replicas < 0 {
+		errs = append(errs, field.Invalid(path.Child("replicas"), *spec.Replicas, "must be greater than or equal to 0"))
+	}
+	for k, v := range spec.SelectorLabels {
+		for _, msg := range validation.IsQualifiedName(k) {
+			errs = append(errs, field.Invalid(path.Child("selectorLabels"), k, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(v) {
+			errs = append(errs, field.Invalid(path.Child("selectorLabels").Key(k), v, msg))
+		}
+	}
+
+	if jwtPolicy := spec.JwtPolicy; jwtPolicy != thirdPartyJwt && jwtPolicy != firstPartyJwt {
+		errs = append(errs, field.NotSupported(path.Child("jwtPolicy"), jwtPolicy, []string{thirdPartyJwt, firstPartyJwt}))
+	}
+
+	errs = append(errs, validateScheduling(spec.TopologySpreadConstraints, spec.PriorityClassName,
+		spec.TerminationGracePeriodSeconds, path)...)
+	errs = append(errs, validateAutoScaling(spec.AutoScaling, path.Child("autoScaling"))...)
+	errs = append(errs, validatePodDisruptionBudget(spec.PodDisruptionBudget, path.Child("podDisruptionBudget"))...)
+
+	if service := spec.Service; service != nil {
+		errs = append(errs, validateServicePorts(service.Ports, path.Child("service", "ports"))...)
+	}
+
+	return errs
+}
+
+// validateScheduling validates the scheduling settings of the pods that the api server would only
+// reject once the Deployment is applied.
+func validateScheduling(constraints []apiv1.TopologySpreadConstraint, priorityClassName string,
+	terminationGracePeriodSeconds *int64, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	for i, c := range constraints {
+		constraintPath := path.Child("topologySpreadConstraints").Index(i)
+		if c.MaxSkew < 1 {
+			errs = append(errs, field.Invalid(constraintPath.Child("maxSkew"), c.MaxSkew, "must be greater than or equal to 1"))
+		}
+		if c.TopologyKey == "" {
+			errs = append(errs, field.Required(constraintPath.Child("topologyKey"), ""))
+		}
+		if c.WhenUnsatisfiable != apiv1.DoNotSchedule && c.WhenUnsatisfiable != apiv1.ScheduleAnyway {
+			errs = append(errs, field.NotSupported(constraintPath.Child("whenUnsatisfiable"), c.WhenUnsatisfiable,
+				[]string{string(apiv1.DoNotSchedule), string(apiv1.ScheduleAnyway)}))
+		}
+	}
+	if priorityClassName != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(priorityClassName) {
+			errs = append(errs, field.Invalid(path.Child("priorityClassName"), priorityClassName, msg))
+		}
+	}
+	if seconds := terminationGracePeriodSeconds; seconds != nil && *seconds < 0 {
+		errs = append(errs, field.Invalid(path.Child("terminationGracePeriodSeconds"), *seconds, "must be greater than or equal to 0"))
+	}
+
+	return errs
+}
+
+func validateAutoScaling(autoScaling *operatorv1beta1.AutoScaling, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if autoScaling == nil || !autoScaling.Enable {
+		return errs
+	}
+
+	if autoScaling.MaxReplicas < 1 {
+		errs = append(errs, field.Invalid(path.Child("maxReplicas"), autoScaling.MaxReplicas,
+			"must be greater than or equal to 1"))
+	}
+	if min := autoScaling.MinReplicas; min != nil {
+		if *min < 1 {
+			errs = append(errs, field.Invalid(path.Child("minReplicas"), *min,
+				"must be greater than or equal to 1"))
+		} else if *min > autoScaling.MaxReplicas {
+			errs = append(errs, field.Invalid(path.Child("maxReplicas"), autoScaling.MaxReplicas,
+				fmt.Sprintf("must be greater than or equal to minReplicas %d", *min)))
+		}
+	}
+
+	return errs
+}
+
+func validatePodDisruptionBudget(budget *operatorv1beta1.PodDisruptionBudget, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if budget == nil || !budget.Enable {
+		return errs
+	}
+
+	if budget.MinAvailable != nil && budget.MaxUnavailable != nil {
+		errs = append(errs, field.Forbidden(path.Child("maxUnavailable"), "may not be set together with minAvailable"))
+	}
+	errs = append(errs, validateIntOrPercent(budget.MinAvailable, path.Child("minAvailable"))...)
+	errs = append(errs, validateIntOrPercent(budget.MaxUnavailable, path.Child("maxUnavailable"))...)
+
+	return errs
+}
+
+// validateIntOrPercent validates a non-negative number or percentage of pods.
+func validateIntOrPercent(value *intstr.IntOrString, path *field.Path) field.ErrorList {
+	if value == nil {
+		return nil
+	}
+	scaled, err := intstr.GetScaledValueFromIntOrPercent(value, 100, false)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, value.String(), err.Error())}
+	}
+	if scaled < 0 {
+		return field.ErrorList{field.Invalid(path, value.String(), "must be greater than or equal to 0")}
+	}
+	return nil
+}
+
+// validateSelectorLabelsUpdate rejects the changes of the selector labels, which are immutable in
+// the selector of the managed Deployment.
+func validateSelectorLabelsUpdate(newSpec, oldSpec *operatorv1beta1.CRDCommonFields, path *field.Path) field.ErrorList {
+	if len(newSpec.SelectorLabels) == 0 && len(oldSpec.SelectorLabels) == 0 {
+		return nil
+	}
+	if !equality.Semantic.DeepEqual(newSpec.SelectorLabels, oldSpec.SelectorLabels) {
+		return field.ErrorList{field.Forbidden(path.Child("selectorLabels"),
+			"is immutable, as it is the selector of the Deployment")}
+	}
+	return nil
+}
+
+// validateContainer validates the image and the ports of a container. If hostNetwork is set, the
+// host ports must match the container ports.
+func validateContainer(container *operatorv1beta1.ContainerCommonFields, hostNetwork bool, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	image := container.Image
+	if image.Repository == "" {
+		errs = append(errs, field.Required(path.Child("image", "repository"), ""))
+	}
+	errs = append(errs, validateImageTag(image.Tag, path.Child("image", "tag"))...)
+	errs = append(errs, validateContainerPorts(container.Ports, hostNetwork, path.Child("ports"))...)
+
+	return errs
+}
+
+func validateImageTag(tag string, path *field.Path) field.ErrorList {
+	if !imageTagPattern.MatchString(tag) {
+		return field.ErrorList{field.Invalid(path, tag, fmt.Sprintf("must match the regex %s", imageTagPattern))}
+	}
+	return nil
+}
+
+// validateContainerPorts validates the ports of a container. If hostNetwork is set, the host ports
+// must match the container ports.
+func validateContainerPorts(containerPorts []apiv1.ContainerPort, hostNetwork bool, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	names := map[string]struct{}{}
+	ports := map[string]struct{}{}
+	for i, port := range containerPorts {
+		portPath := path.Index(i)
+		if port.Name != "" {
+			for _, msg := range validation.IsValidPortName(port.Name) {
+				errs = append(errs, field.Invalid(portPath.Child("name"), port.Name, msg))
+			}
+			if _, ok := names[port.Name]; ok {
+				errs = append(errs, field.Duplicate(portPath.Child("name"), port.Name))
+			}
+			names[port.Name] = struct{}{}
+		}
+		for _, msg := range validation.IsValidPortNum(int(port.ContainerPort)) {
+			errs = append(errs, field.Invalid(portPath.Child("containerPort"), port.ContainerPort, msg))
+		}
+		if port.HostPort != 0 {
+			for _, msg := range validation.IsValidPortNum(int(port.HostPort)) {
+				errs = append(errs, field.Invalid(portPath.Child("hostPort"), port.HostPort, msg))
+			}
+			if hostNetwork && port.HostPort != port.ContainerPort {
+				errs = append(errs, field.Invalid(portPath.Child("hostPort"), port.HostPort,
+					"must match containerPort when hostNetwork is enabled"))
+			}
+		}
+
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = apiv1.ProtocolTCP
+		}
+		key := fmt.Sprintf("%d/%s", port.ContainerPort, protocol)
+		if _, ok := ports[key]; ok {
+			errs = append(errs, field.Duplicate(portPath.Child("containerPort"), key))
+		}
+		ports[key] = struct{}{}
+	}
+
+	return errs
+}
+
+func validateServicePorts(servicePorts []apiv1.ServicePort, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	names := map[string]struct{}{}
+	for i, port := range servicePorts {
+		portPath := path.Index(i)
+		if port.Name == "" && len(servicePorts) > 1 {
+			errs = append(errs, field.Required(portPath.Child("name"), "must be set when there are several ports"))
+		}
+		if port.Name != "" {
+			if _, ok := names[port.Name]; ok {
+				errs = append(errs, field.Duplicate(portPath.Child("name"), port.Name))
+			}
+			names[port.Name] = struct{}{}
+		}
+		for _, msg := range validation.IsValidPortNum(int(port.Port)) {
+			errs = append(errs, field.Invalid(portPath.Child("port"), port.Port, msg))
+		}
+	}
+
+	return errs
+}
+
+// toInvalid returns the Invalid error of the kind object name for errs, or nil if errs is empty.
+func toInvalid(kind, name string, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(operatorv1beta1.GroupVersion.WithKind(kind).GroupKind(), name, errs)
+}
+
+// crdWarnings warns that the CRDs are not installed for a controller image tag older than the
+// embedded CRD releases, which are then left to the tools that installed them.
+func crdWarnings(tag string, policy operatorv1beta1.CRDInstallPolicy) admission.Warnings {
+	if policy == operatorv1beta1.CRDInstallPolicySkip {
+		return nil
+	}
+	if _, err := crds.Select(tag); err != nil {
+		return admission.Warnings{fmt.Sprintf("the CRDs are not installed: %v", err)}
+	}
+	return nil
+}
+
+// validateGatewayAPI validates the Gateway API release and, if the operator installs its CRDs, that
+// the CRDs of the release are embedded, as the controller would otherwise enable the Gateway API
+// support without them.
+func validateGatewayAPI(gatewayAPI *operatorv1beta1.GatewayAPI, install bool, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if gatewayAPI == nil {
+		return errs
+	}
+
+	if gatewayAPI.Version != "" {
+		if _, err := version.ParseGeneric(gatewayAPI.Version); err != nil {
+			return append(errs, field.Invalid(path.Child("version"), gatewayAPI.Version, err.Error()))
+		}
+	}
+	if gatewayAPI.Enable && install {
+		channel := gatewayAPI.Channel
+		if channel == "" {
+			channel = operatorv1beta1.GatewayAPIChannelStandard
+		}
+		if _, err := crds.GetGatewayAPI(gatewayAPI.Version, string(channel)); err != nil {
+			errs = append(errs, field.Invalid(path.Child("enable"), gatewayAPI.Enable, err.Error()))
+		}
+	}
+	return errs
+}
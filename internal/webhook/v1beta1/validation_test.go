@@ -0,0 +1,37 @@
+package v1beta1
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateImageTag(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a1", 32)
+	tests := []struct {
+		tag   string
+		valid bool
+	}{
+		{tag: "1.3.0", valid: true},
+		{tag: "v1.3.0-rc.1_amd64", valid: true},
+		{tag: "latest", valid: true},
+		{tag: digest, valid: true},
+		{tag: "@" + digest, valid: true},
+		{tag: "1.3.0@" + digest, valid: true},
+		{tag: ""},
+		{tag: "-1.3.0"},
+		{tag: "1.3.0:latest"},
+		{tag: "sha256:abc"},
+		{tag: "1.3.0@"},
+		{tag: "1.3.0@" + digest + "@" + digest},
+		{tag: strings.Repeat("a", 129)},
+	}
+
+	for _, tt := range tests {
+		errs := validateImageTag(tt.tag, field.NewPath("spec", "image", "tag"))
+		if tt.valid != (len(errs) == 0) {
+			t.Errorf("validateImageTag(%q) = %v, want valid %v", tt.tag, errs, tt.valid)
+		}
+	}
+}